@@ -0,0 +1,39 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func (client *Client) InjectAuthenticationHeader(req *http.Request, path string) (*http.Request, error) {
+	ctx := client.logContext(req.Context())
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Injecting the authentication header")
+	// client.l.Lock()
+	// defer client.l.Unlock()
+	if client.apiToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.apiToken))
+		return req, nil
+	} else if client.tokenSource != nil {
+		token, err := client.tokenSource.Token(ctx)
+		if err != nil {
+			return req, fmt.Errorf("%w: %w", ErrAuth, err)
+		}
+		client.addToken(token)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		return req, nil
+	} else if client.adminCert != "" && client.privatekey != "" {
+		// the client is authenticated by the client certificate presented in the TLS handshake, see tlsConfig
+		tflog.SubsystemTrace(ctx, LogSubsystem, "Authenticating with the client certificate", map[string]interface{}{"method": req.Method, "path": path})
+		return req, nil
+	} else {
+		return req, fmt.Errorf("An Hyperfabric API Bearer Token or a client certificate is required. Set the `HYPERFABRIC_TOKEN` environment variable or set the `token` attribute under the provider configuration, or set the `token_command` or `token_file` attribute, or the `client_id`, `client_secret` and `token_url` attributes of an OAuth2 client, or the `client_cert_pem` and `client_key_pem` attributes")
+	}
+}
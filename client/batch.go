@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// createBatchWindow is the time during which the creations of objects in the same collection are coalesced into a
+// single request, from the first creation of the batch.
+const createBatchWindow = 100 * time.Millisecond
+
+// maxCreateBatchSize is the maximum number of objects created by a single request.
+const maxCreateBatchSize = 100
+
+// BatchCreates option: coalesces the concurrent creations of objects in the same collection, such as the VNIs of a
+// fabric, into a single request with an array of objects. The creations requested within 100 milliseconds of the first
+// one are sent together, up to 100 objects per request, and the objects of the response are returned to each caller
+// in the order of the request.
+func BatchCreates(batchCreates bool) Option {
+	return func(client *Client) {
+		client.batchCreates = batchCreates
+	}
+}
+
+// createBatcher collects the pending batches of creations of the client by collection.
+type createBatcher struct {
+	lock    sync.Mutex
+	pending map[string]*createBatch
+}
+
+// createBatch is a batch of creations of objects in a collection, sent by a single request.
+type createBatch struct {
+	// ctx is the context of the first creation of the batch without its cancellation, so that the request is sent
+	// with its log fields and in its trace even when the first caller gave up.
+	ctx      context.Context
+	method   string
+	path     string
+	envelope string
+	objects  []interface{}
+	// ctxs are the contexts of the creations of objects, the objects of the cancelled creations are not sent.
+	ctxs []context.Context
+	sent bool
+	done chan struct{}
+	// created and errs hold the created object and the error of each object of the batch once done is closed.
+	created []json.RawMessage
+	errs    []error
+}
+
+// create adds object to the batch of the collection at path and returns the created object, or nil when the
+// Hyperfabric service did not return it, once the batch is sent.
+func (b *createBatcher) create(ctx context.Context, c *Client, method, path, envelope string, object interface{}) (json.RawMessage, error) {
+	key := method + " " + path
+	b.lock.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &createBatch{
+			ctx:      context.WithoutCancel(ctx),
+			method:   method,
+			path:     path,
+			envelope: envelope,
+			done:     make(chan struct{}),
+		}
+		if b.pending == nil {
+			b.pending = map[string]*createBatch{}
+		}
+		b.pending[key] = batch
+		time.AfterFunc(createBatchWindow, func() { b.send(c, key, batch) })
+	}
+	index := len(batch.objects)
+	batch.objects = append(batch.objects, object)
+	batch.ctxs = append(batch.ctxs, ctx)
+	full := len(batch.objects) >= maxCreateBatchSize
+	b.lock.Unlock()
+	if full {
+		go b.send(c, key, batch)
+	}
+
+	select {
+	case <-batch.done:
+		return batch.created[index], batch.errs[index]
+	case <-ctx.Done():
+		return nil, fmt.Errorf("the creation of the object in %s was cancelled: %w", path, ctx.Err())
+	}
+}
+
+// send sends batch once, when its window elapsed or it is full, and releases the callers waiting for it.
+func (b *createBatcher) send(c *Client, key string, batch *createBatch) {
+	b.lock.Lock()
+	if batch.sent {
+		b.lock.Unlock()
+		return
+	}
+	batch.sent = true
+	if b.pending[key] == batch {
+		delete(b.pending, key)
+	}
+	b.lock.Unlock()
+
+	defer close(batch.done)
+	batch.created = make([]json.RawMessage, len(batch.objects))
+	batch.errs = make([]error, len(batch.objects))
+	// The objects of the creations cancelled while the batch was pending are not sent, since no caller would manage
+	// them once created. indexes holds the index in the batch of each sent object.
+	objects := make([]interface{}, 0, len(batch.objects))
+	indexes := make([]int, 0, len(batch.objects))
+	for i, object := range batch.objects {
+		if err := batch.ctxs[i].Err(); err != nil {
+			batch.errs[i] = fmt.Errorf("the creation of the object in %s was cancelled: %w", batch.path, err)
+			continue
+		}
+		objects = append(objects, object)
+		indexes = append(indexes, i)
+	}
+	if len(objects) == 0 {
+		return
+	}
+	tflog.SubsystemDebug(c.logContext(batch.ctx), LogSubsystem, "Sending a batch of creations", map[string]interface{}{"method": batch.method, "path": batch.path, "objects": len(objects)})
+	created, err := c.createObjects(batch.ctx, batch.method, batch.path, batch.envelope, objects)
+	var apiError *APIError
+	if err != nil && len(objects) > 1 && errors.As(err, &apiError) && apiError.IsUserError() {
+		// The Hyperfabric service rejects the whole batch when one of its objects is invalid, the objects are created
+		// one by one to return the error of each invalid object to its own caller.
+		tflog.SubsystemDebug(c.logContext(batch.ctx), LogSubsystem, "Batch of creations rejected, creating the objects one by one", map[string]interface{}{"method": batch.method, "path": batch.path, "error": err})
+		var wg sync.WaitGroup
+		for i, object := range objects {
+			wg.Add(1)
+			go func(i int, object interface{}) {
+				defer wg.Done()
+				created, err := c.createObjects(batch.ctx, batch.method, batch.path, batch.envelope, []interface{}{object})
+				if len(created) > 0 {
+					batch.created[indexes[i]] = created[0]
+				}
+				batch.errs[indexes[i]] = err
+			}(i, object)
+		}
+		wg.Wait()
+		return
+	}
+	for i, index := range indexes {
+		if i < len(created) {
+			batch.created[index] = created[i]
+		}
+		batch.errs[index] = err
+	}
+}
+
+// createObjects wraps objects in the envelope expected by the collection at path and returns the objects of the
+// response, in the order of the request.
+func (c *Client) createObjects(ctx context.Context, method, path, envelope string, objects []interface{}) ([]json.RawMessage, error) {
+	var response map[string]json.RawMessage
+	found, err := c.doJSON(ctx, method, path, map[string][]interface{}{envelope: objects}, &response)
+	if err != nil || !found {
+		return nil, err
+	}
+	return decodeEnvelope[json.RawMessage](response, envelope, path)
+}
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newVniServer returns a server creating the VNIs of its POST requests with the ID id-<name>, or rejecting the
+// whole request when one of the VNIs is named invalid, and the sizes of the POST requests it received.
+func newVniServer(t *testing.T) (*httptest.Server, func() []int) {
+	var lock sync.Mutex
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		lock.Lock()
+		batchSizes = append(batchSizes, len(body["vnis"]))
+		lock.Unlock()
+		for i, vni := range body["vnis"] {
+			if vni["name"] == "invalid" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, `{"errCode": "ERR_CODE_BAD_REQUEST", "status": 400, "field": "vnis[%d].name"}`, i)
+				return
+			}
+			vni["id"] = fmt.Sprintf("id-%s", vni["name"])
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []int {
+		lock.Lock()
+		defer lock.Unlock()
+		return batchSizes
+	}
+}
+
+// createVnis creates concurrently the VNIs of names and returns the created VNIs and the errors by name.
+func createVnis(c *Client, names []string) (map[string]*Vni, map[string]error) {
+	var lock sync.Mutex
+	created, errs := map[string]*Vni{}, map[string]error{}
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			vni, err := c.Vnis.Create(context.Background(), "f1", &Vni{Name: String(name)})
+			lock.Lock()
+			defer lock.Unlock()
+			created[name], errs[name] = vni, err
+		}(name)
+	}
+	wg.Wait()
+	return created, errs
+}
+
+func TestBatchCreates(t *testing.T) {
+	server, batchSizes := newVniServer(t)
+	c := NewClient(server.URL, "token", MaxRetries(0), BatchCreates(true))
+
+	names := make([]string, 10)
+	for i := range names {
+		names[i] = fmt.Sprintf("vni%d", i)
+	}
+	created, errs := createVnis(c, names)
+	for _, name := range names {
+		if errs[name] != nil || created[name] == nil || created[name].Id != "id-"+name {
+			t.Errorf("expected the VNI %s to be created with its own ID, got %+v, err: %v", name, created[name], errs[name])
+		}
+	}
+	if sizes := batchSizes(); len(sizes) != 1 || sizes[0] != 10 {
+		t.Errorf("expected the creations to be sent in a single request, got requests of %v objects", sizes)
+	}
+}
+
+func TestBatchCreatesWithInvalidObject(t *testing.T) {
+	server, batchSizes := newVniServer(t)
+	c := NewClient(server.URL, "token", MaxRetries(0), BatchCreates(true))
+
+	created, errs := createVnis(c, []string{"vni1", "invalid", "vni2"})
+	for _, name := range []string{"vni1", "vni2"} {
+		if errs[name] != nil || created[name] == nil || created[name].Id != "id-"+name {
+			t.Errorf("expected the valid VNI %s to be created, got %+v, err: %v", name, created[name], errs[name])
+		}
+	}
+	var apiError *APIError
+	if !errors.As(errs["invalid"], &apiError) || apiError.RestError.Field != "vnis[0].name" {
+		t.Errorf("expected the error of its own request for the invalid VNI, got %v", errs["invalid"])
+	}
+	if sizes := batchSizes(); len(sizes) != 4 || sizes[0] != 3 {
+		t.Errorf("expected the rejected batch to be followed by a request per object, got requests of %v objects", sizes)
+	}
+}
+
+func TestCreatesWithoutBatches(t *testing.T) {
+	server, batchSizes := newVniServer(t)
+	c := NewClient(server.URL, "token", MaxRetries(0))
+
+	if _, errs := createVnis(c, []string{"vni1", "vni2", "vni3"}); errs["vni1"] != nil || errs["vni2"] != nil || errs["vni3"] != nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if sizes := batchSizes(); len(sizes) != 3 {
+		t.Errorf("expected a request per creation, got requests of %v objects", sizes)
+	}
+}
+
+func TestBatchCreatesWithCancelledCreation(t *testing.T) {
+	server, batchSizes := newVniServer(t)
+	c := NewClient(server.URL, "token", MaxRetries(0), BatchCreates(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error, 1)
+	go func() {
+		_, err := c.Vnis.Create(ctx, "f1", &Vni{Name: String("cancelled")})
+		cancelledErr <- err
+	}()
+	// The creation is cancelled while its batch is pending.
+	time.Sleep(createBatchWindow / 4)
+	cancel()
+	if err := <-cancelledErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled creation to return a cancellation error, got %v", err)
+	}
+	created, errs := createVnis(c, []string{"vni1", "vni2"})
+	for _, name := range []string{"vni1", "vni2"} {
+		if errs[name] != nil || created[name] == nil || created[name].Id != "id-"+name {
+			t.Errorf("expected the VNI %s to be created with its own ID, got %+v, err: %v", name, created[name], errs[name])
+		}
+	}
+	if sizes := batchSizes(); len(sizes) != 1 || sizes[0] != 2 {
+		t.Errorf("expected the cancelled creation to be dropped from the batch, got requests of %v objects", sizes)
+	}
+}
@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// BearerToken is an API bearer token. The token itself is only returned when the bearer token is created.
+type BearerToken struct {
+	TokenId     string    `json:"tokenId,omitempty"`
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	NotAfter    *string   `json:"notAfter,omitempty"`
+	NotBefore   *string   `json:"notBefore,omitempty"`
+	Scope       *string   `json:"scope,omitempty"`
+	Token       *string   `json:"token,omitempty"`
+	Metadata    *Metadata `json:"metadata,omitempty"`
+}
+
+// BearerTokensService handles the /api/v1/bearerTokens endpoints.
+type BearerTokensService service
+
+// Get returns the bearer token identified by tokenId, or nil when it does not exist.
+func (s *BearerTokensService) Get(ctx context.Context, tokenId string) (*BearerToken, error) {
+	return getObject[BearerToken](ctx, s.client, fmt.Sprintf("/api/v1/bearerTokens/%s", tokenId))
+}
+
+// Create creates a bearer token and returns it, including the token, as returned by the Hyperfabric service.
+func (s *BearerTokensService) Create(ctx context.Context, bearerToken *BearerToken) (*BearerToken, error) {
+	var response struct {
+		Tokens []BearerToken `json:"tokens"`
+		// The token may also be returned next to the list of created tokens.
+		Token string `json:"token"`
+	}
+	found, err := s.client.doJSON(ctx, "POST", "/api/v1/bearerTokens", map[string][]*BearerToken{"tokens": {bearerToken}}, &response)
+	if err != nil || !found {
+		return nil, err
+	}
+	if len(response.Tokens) == 0 {
+		return nil, nil
+	}
+	created := response.Tokens[0]
+	if response.Token != "" {
+		created.Token = &response.Token
+	}
+	return &created, nil
+}
+
+// Delete deletes the bearer token identified by tokenId.
+func (s *BearerTokensService) Delete(ctx context.Context, tokenId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/bearerTokens/%s", tokenId))
+}
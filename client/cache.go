@@ -0,0 +1,170 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/gabs/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// responseCache caches the responses of GET requests by path for the life of a client.
+// Concurrent identical GET requests are collapsed into a single request to the Hyperfabric service.
+// Any POST, PUT or DELETE request invalidates the cached paths it affects, see affectedBy.
+type responseCache struct {
+	lock    sync.Mutex
+	entries map[string]*cacheEntry
+	// generation is incremented on every invalidation, so a GET response received after an invalidation is not cached.
+	generation uint64
+	group      singleflight.Group
+	// inFlight holds the paths of the GET requests being sent with their generation, the requests are forgotten
+	// by the group on invalidation.
+	inFlight map[string]uint64
+	// fetchTimeout bounds the GET requests shared by the callers, which are not cancelled with their contexts.
+	fetchTimeout time.Duration
+}
+
+// cacheEntry is a cached response, found is false when the object does not exist.
+type cacheEntry struct {
+	found bool
+	body  []byte
+}
+
+type cacheResult struct {
+	entry     *cacheEntry
+	diagError *DiagError
+}
+
+func newResponseCache(fetchTimeout time.Duration) *responseCache {
+	return &responseCache{
+		entries:      make(map[string]*cacheEntry),
+		inFlight:     make(map[string]uint64),
+		fetchTimeout: fetchTimeout,
+	}
+}
+
+// get returns the cached response of path or calls fetch, once for all concurrent callers, to retrieve it.
+// The fetch is not cancelled with ctx since the other callers wait for it, it is bounded by fetchTimeout instead,
+// and each caller stops waiting when its own ctx is done. Errors are not cached.
+func (rc *responseCache) get(ctx context.Context, path string, fetch func(context.Context) (*gabs.Container, *DiagError)) (*gabs.Container, *DiagError) {
+	rc.lock.Lock()
+	entry, ok := rc.entries[path]
+	rc.lock.Unlock()
+	if ok {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Using the cached response", map[string]interface{}{"method": "GET", "path": path})
+		return entry.container()
+	}
+
+	resultChan := rc.group.DoChan(path, func() (interface{}, error) {
+		rc.lock.Lock()
+		generation := rc.generation
+		rc.inFlight[path] = generation
+		rc.lock.Unlock()
+
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rc.fetchTimeout)
+		defer cancel()
+		container, diagError := fetch(fetchCtx)
+		result := cacheResult{diagError: diagError}
+		rc.lock.Lock()
+		defer rc.lock.Unlock()
+		if inFlightGeneration, ok := rc.inFlight[path]; ok && inFlightGeneration == generation {
+			delete(rc.inFlight, path)
+		}
+		if diagError == nil {
+			result.entry = &cacheEntry{found: container != nil}
+			if container != nil {
+				result.entry.body = container.Bytes()
+			}
+			if rc.generation == generation {
+				rc.entries[path] = result.entry
+			}
+		}
+		return result, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, getDiagError(
+			fmt.Sprintf("The GET REST request to %s was cancelled", path),
+			fmt.Sprintf("The operation was interrupted before the Hyperfabric service responded: %v.", ctx.Err()),
+		)
+	case response := <-resultChan:
+		result := response.Val.(cacheResult)
+		if result.diagError != nil {
+			return nil, result.diagError
+		}
+		return result.entry.container()
+	}
+}
+
+// invalidate removes the cached responses affected by a POST, PUT or DELETE request to path.
+func (rc *responseCache) invalidate(path string) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	rc.generation++
+	for cachedPath := range rc.entries {
+		if affectedBy(cachedPath, path) {
+			delete(rc.entries, cachedPath)
+		}
+	}
+	// Later GET requests must not join a request sent before the invalidation.
+	for inFlightPath := range rc.inFlight {
+		if affectedBy(inFlightPath, path) {
+			rc.group.Forget(inFlightPath)
+			delete(rc.inFlight, inFlightPath)
+		}
+	}
+}
+
+// affectedBy reports whether the response of a GET request to cachedPath may be changed by a request to path.
+// These are the ancestors of path, such as the collection it belongs to, and everything below the top-level
+// object of path, since changes to the objects of a fabric also change the fabric and its other objects.
+// The devices are also affected by any change of a fabric, since binding a device to a node changes the device.
+func affectedBy(cachedPath, path string) bool {
+	cachedPath = trimQuery(cachedPath)
+	path = trimQuery(path)
+	if cachedPath == path || strings.HasPrefix(path, cachedPath+"/") {
+		return true
+	}
+	// The top-level object is the object below /api/v1/{collection}, such as /api/v1/fabrics/{fabricId}.
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 5)
+	if len(segments) >= 4 {
+		topLevelObject := "/" + strings.Join(segments[:4], "/")
+		if strings.HasPrefix(cachedPath, topLevelObject+"/") {
+			return true
+		}
+		if segments[2] == "fabrics" && strings.HasPrefix(cachedPath, "/api/v1/devices") {
+			return true
+		}
+	}
+	return false
+}
+
+func trimQuery(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+// container returns a copy of the cached response, so callers cannot modify the cache.
+func (e *cacheEntry) container() (*gabs.Container, *DiagError) {
+	if !e.found {
+		return nil, nil
+	}
+	container, err := gabs.ParseJSON(e.body)
+	if err != nil {
+		return nil, getDiagError("Decoding of a cached response failed", err.Error())
+	}
+	return container, nil
+}
@@ -0,0 +1,161 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheGetRequestsCollapsesConcurrentGets(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"devices": [{"deviceId": "d1"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0), CacheGetRequests(true))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			devices, err := c.Devices.List(context.Background())
+			if err != nil || len(devices) != 1 {
+				t.Errorf("unexpected devices %v, err: %v", devices, err)
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if _, err := c.Devices.List(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected 1 request to the Hyperfabric service, got %d", got)
+	}
+}
+
+func TestCacheGetRequestsInvalidatedOnWrite(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			atomic.AddInt32(&gets, 1)
+		}
+		w.Write([]byte(`{"id": "n1", "name": "node1"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0), CacheGetRequests(true))
+	ctx := context.Background()
+
+	c.Nodes.Get(ctx, "f1", "n1")
+	c.Nodes.Get(ctx, "f1", "n1")
+	if got := atomic.LoadInt32(&gets); got != 1 {
+		t.Fatalf("expected the second GET to be served from the cache, got %d requests", got)
+	}
+
+	c.Nodes.Update(ctx, "f1", "n1", &Node{Name: String("node1")})
+	c.Nodes.Get(ctx, "f1", "n1")
+	if got := atomic.LoadInt32(&gets); got != 2 {
+		t.Errorf("expected the GET after the PUT to be sent, got %d requests", got)
+	}
+}
+
+func TestCacheGetRequestsNotCancelledByFirstCaller(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+		w.Write([]byte(`{"devices": [{"deviceId": "d1"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0), CacheGetRequests(true))
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.Devices.List(firstCtx)
+		firstErr <- err
+	}()
+	<-received
+	secondDevices := make(chan []Device, 1)
+	go func() {
+		devices, err := c.Devices.List(context.Background())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		secondDevices <- devices
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; err == nil {
+		t.Errorf("expected the cancelled caller to stop waiting with an error")
+	}
+	close(release)
+	if devices := <-secondDevices; len(devices) != 1 {
+		t.Errorf("expected the other caller to receive the response of the shared request, got %v", devices)
+	}
+}
+
+func TestCacheGetRequestsDoesNotCacheErrors(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&gets, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errCode": "ERR_CODE_BAD_REQUEST", "status": 400}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0), CacheGetRequests(true))
+	for i := 0; i < 2; i++ {
+		if _, err := c.Fabrics.Get(context.Background(), "f1"); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if got := atomic.LoadInt32(&gets); got != 2 {
+		t.Errorf("expected 2 requests to the Hyperfabric service, got %d", got)
+	}
+}
+
+func TestAffectedBy(t *testing.T) {
+	tests := []struct {
+		cachedPath string
+		path       string
+		affected   bool
+	}{
+		{"/api/v1/fabrics/f1/nodes/n1", "/api/v1/fabrics/f1/nodes/n1", true},
+		{"/api/v1/fabrics/f1/nodes", "/api/v1/fabrics/f1/nodes/n1", true},
+		{"/api/v1/fabrics/f1", "/api/v1/fabrics/f1/nodes/n1", true},
+		{"/api/v1/fabrics/f1/nodes/n1/ports/p1", "/api/v1/fabrics/f1/nodes/n1", true},
+		{"/api/v1/fabrics/f1/vnis/v1", "/api/v1/fabrics/f1/nodes/n1", true},
+		{"/api/v1/devices", "/api/v1/fabrics/f1/nodes/n1/devices/d1", true},
+		{"/api/v1/fabrics/f2/nodes/n1", "/api/v1/fabrics/f1/nodes/n1", false},
+		{"/api/v1/users/u1", "/api/v1/fabrics/f1", false},
+		{"/api/v1/users/u1", "/api/v1/users/u2", false},
+		{"/api/v1/users?page=1", "/api/v1/users", true},
+	}
+	for _, test := range tests {
+		if got := affectedBy(test.cachedPath, test.path); got != test.affected {
+			t.Errorf("affectedBy(%q, %q) = %v, expected %v", test.cachedPath, test.path, got, test.affected)
+		}
+	}
+}
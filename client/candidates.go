@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrCandidateAPIDisabled is returned by CandidatesService.Get unless the ExperimentalCandidateAPI option is set.
+var ErrCandidateAPIDisabled = errors.New("the candidate configuration API is experimental and disabled")
+
+// FabricCandidate is a candidate configuration of a fabric, with the changes made to the fabric since its last commit.
+type FabricCandidate struct {
+	FabricId string            `json:"fabricId,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Changes  []CandidateChange `json:"changes,omitempty"`
+	// Raw is the candidate configuration as returned by the Hyperfabric service.
+	Raw json.RawMessage `json:"-"`
+}
+
+// CandidateChange is a pending change of an object of a fabric in a candidate configuration.
+type CandidateChange struct {
+	// ObjectType is the type of the changed object, such as node, port or VNI.
+	ObjectType string `json:"objectType,omitempty"`
+	ObjectId   string `json:"objectId,omitempty"`
+	// Operation is CREATE, UPDATE or DELETE.
+	Operation string `json:"operation,omitempty"`
+	// Fields are the names of the changed attributes of the object.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// CandidatesService handles the /api/v1/fabrics/{fabricId}/candidates endpoints of the candidate configurations of
+// the fabrics, which hold the changes made to a fabric until they are committed to its running configuration.
+type CandidatesService service
+
+// ExperimentalCandidateAPI option: enables CandidatesService.Get. The GET endpoint of the candidate configurations is
+// not documented by the Hyperfabric API, so it may change or be missing on a given Hyperfabric service.
+func ExperimentalCandidateAPI(enabled bool) Option {
+	return func(client *Client) {
+		client.experimentalCandidateAPI = enabled
+	}
+}
+
+// Get returns the candidate configuration named candidate of the fabric identified by fabricId, or nil when it does not
+// exist. It returns ErrCandidateAPIDisabled unless the ExperimentalCandidateAPI option is set.
+func (s *CandidatesService) Get(ctx context.Context, fabricId, candidate string) (*FabricCandidate, error) {
+	if !s.client.experimentalCandidateAPI {
+		return nil, ErrCandidateAPIDisabled
+	}
+	path := fmt.Sprintf("/api/v1/fabrics/%s/candidates/%s", fabricId, candidate)
+	raw, err := getObject[json.RawMessage](ctx, s.client, path)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	var found FabricCandidate
+	if err := json.Unmarshal(*raw, &found); err != nil {
+		return nil, fmt.Errorf("decoding of the JSON response of GET %s failed: %w", path, err)
+	}
+	found.Raw = *raw
+	return &found, nil
+}
+
+// Commit commits the candidate configuration named candidate of the fabric identified by fabricId to its running
+// configuration, with comments describing the change.
+func (s *CandidatesService) Commit(ctx context.Context, fabricId, candidate, comments string) error {
+	_, err := s.client.doJSON(ctx, "POST", fmt.Sprintf("/api/v1/fabrics/%s/candidates/%s", fabricId, candidate), map[string]string{"comments": comments}, nil)
+	return err
+}
@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newCandidateServer returns a server recording its requests.
+func newCandidateServer(t *testing.T) (*httptest.Server, func() []string) {
+	var lock sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		lock.Unlock()
+		if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/vnis") {
+			w.Write([]byte(`{"vnis": [{"id": "v1", "fabricId": "f1"}]}`))
+			return
+		}
+		w.Write([]byte(`{"id": "v1", "fabricId": "f1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		recorded := requests
+		requests = nil
+		return recorded
+	}
+}
+
+func TestCandidate(t *testing.T) {
+	server, requests := newCandidateServer(t)
+	c := NewClient(server.URL, "token", MaxRetries(0), Candidate("pipeline"), AutoCommit(true))
+	ctx := context.Background()
+
+	if _, err := c.Vnis.Create(ctx, "f1", &Vni{Name: String("vni1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.AddChangedFabric("f1")
+	c.AddChangedFabric("f2")
+	c.DoAutoCommit()
+	got := requests()
+	sort.Strings(got)
+	expected := []string{
+		"POST /api/v1/fabrics/f1/candidates/pipeline",
+		"POST /api/v1/fabrics/f1/vnis",
+		"POST /api/v1/fabrics/f2/candidates/pipeline",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the commit of the candidate configuration of the client in every changed fabric, got %q", got)
+	}
+
+	c.DoAutoCommit()
+	if got := requests(); len(got) != 0 {
+		t.Errorf("expected the committed fabrics to be forgotten, got %q", got)
+	}
+}
+
+func TestCommittedCandidate(t *testing.T) {
+	server, requests := newCandidateServer(t)
+	c := NewClient(server.URL, "token", MaxRetries(0), Candidate("pipeline"), AutoCommit(true))
+
+	c.AddChangedFabric("f1")
+	c.AddChangedFabric("f2")
+	c.CommittedCandidate("f1", "pipeline")
+	// The commit of another candidate configuration does not commit the changes of the client.
+	c.CommittedCandidate("f2", DefaultCandidate)
+	c.DoAutoCommit()
+	if got, expected := requests(), []string{"POST /api/v1/fabrics/f2/candidates/pipeline"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the commit of the fabric not committed yet only, got %q", got)
+	}
+}
+
+func TestExperimentalCandidateAPI(t *testing.T) {
+	server, requests := newCandidateServer(t)
+	ctx := context.Background()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	if _, err := c.Candidates.Get(ctx, "f1", DefaultCandidate); !errors.Is(err, ErrCandidateAPIDisabled) {
+		t.Errorf("expected ErrCandidateAPIDisabled without the ExperimentalCandidateAPI option, got %v", err)
+	}
+	if got := requests(); len(got) != 0 {
+		t.Errorf("expected no request to the disabled candidate configuration API, got %q", got)
+	}
+
+	c = NewClient(server.URL, "token", MaxRetries(0), ExperimentalCandidateAPI(true))
+	if _, err := c.Candidates.Get(ctx, "f1", DefaultCandidate); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got, expected := requests(), []string{"GET /api/v1/fabrics/f1/candidates/default"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the GET request of the candidate configuration, got %q", got)
+	}
+}
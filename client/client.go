@@ -0,0 +1,919 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/gabs/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Default timeout for NGINX in Hyperfabric service is 90 Seconds.
+// Allow the client to set a shorter or longer time depending on their
+// environment
+const DefaultReqTimeoutVal int = 100
+const DefaultBackoffMinDelay int = 4
+const DefaultBackoffMaxDelay int = 60
+const DefaultBackoffDelayFactor float64 = 3
+const DefaultCandidate string = "default"
+
+// Client is the main entry point
+type Client struct {
+	baseURL    *url.URL
+	pathURL    string
+	httpClient *http.Client
+	apiToken   string
+	// tokenSource provides the tokens of the client when apiToken is not set, see OAuth2ClientCredentials, TokenCommand
+	// and TokenFile.
+	tokenSource tokenSource
+	// tokens are the tokens obtained from tokenSource, which are masked in the logs, see secrets.
+	tokens       []string
+	lockTokens   sync.Mutex
+	clientSecret string
+	privatekey   string
+	adminCert    string
+	caCert       string
+	// transportErr is the error of the configuration of the transport returned by the requests, see newTransport
+	transportErr       error
+	insecure           bool
+	reqTimeoutSet      bool
+	reqTimeoutVal      uint32
+	proxyUrl           string
+	proxyCreds         string
+	noProxy            string
+	preserveBaseUrlRef bool
+	skipLoggingPayload bool
+	maxRetries         int
+	backoffMinDelay    int
+	backoffMaxDelay    int
+	backoffDelayFactor float64
+	autoCommit         bool
+	candidate          string
+	retryOnConflict    bool
+	// experimentalCandidateAPI enables the undocumented GET endpoint of the candidate configurations, see
+	// ExperimentalCandidateAPI.
+	experimentalCandidateAPI bool
+	// requestSlots bounds the number of requests in flight and rateLimiter the number of requests per second,
+	// both are shared by every resource and data source using the client.
+	maxConcurrentRequests int
+	requestSlots          chan struct{}
+	requestsPerSecond     float64
+	rateLimiter           *rateLimiter
+	// responseCache caches the responses of GET requests when cacheGetRequests is set, see CacheGetRequests.
+	cacheGetRequests bool
+	responseCache    *responseCache
+	// tracerProvider provides the tracer of the spans of the requests, see TracerProvider.
+	tracerProvider trace.TracerProvider
+	// createBatcher coalesces the concurrent creations in the same collection when batchCreates is set, see BatchCreates.
+	batchCreates  bool
+	createBatcher createBatcher
+	// stats collects the usage of the Hyperfabric API by the client, see Stats.
+	stats apiStats
+	// lockRequest        sync.Mutex
+	// changedFabrics holds the ids of the fabrics changed through the client, see DoAutoCommit.
+	changedFabrics    map[string]bool
+	lockChangedFabric sync.Mutex
+
+	// Typed services of the Hyperfabric API, see initServices.
+	common          service
+	BearerTokens    *BearerTokensService
+	Candidates      *CandidatesService
+	Connections     *ConnectionsService
+	Devices         *DevicesService
+	Fabrics         *FabricsService
+	Loopbacks       *LoopbacksService
+	ManagementPorts *ManagementPortsService
+	Nodes           *NodesService
+	Ports           *PortsService
+	SubInterfaces   *SubInterfacesService
+	Users           *UsersService
+	Vnis            *VnisService
+	Vrfs            *VrfsService
+}
+
+type Option func(*Client)
+
+func Insecure(insecure bool) Option {
+	return func(client *Client) {
+		client.insecure = insecure
+	}
+}
+
+func PathURL(pathUrl string) Option {
+	return func(sm *Client) {
+		sm.pathURL = pathUrl
+	}
+}
+
+func APIToken(apiToken string) Option {
+	return func(client *Client) {
+		client.apiToken = apiToken
+	}
+}
+
+// PrivateKey option: PEM encoded private key of the client certificate of the AdminCert option.
+func PrivateKey(privatekey string) Option {
+	return func(client *Client) {
+		client.privatekey = privatekey
+	}
+}
+
+// AdminCert option: PEM encoded client certificate presented to the Hyperfabric service to authenticate the client
+// when no API token is set, with the private key of the PrivateKey option.
+func AdminCert(adminCert string) Option {
+	return func(client *Client) {
+		client.adminCert = adminCert
+	}
+}
+
+// ProxyUrl option: URL of the HTTP, HTTPS or SOCKS5 proxy of the requests, replacing the proxies of the HTTPS_PROXY
+// and HTTP_PROXY environment variables, see proxyFunc.
+func ProxyUrl(pUrl string) Option {
+	return func(client *Client) {
+		client.proxyUrl = pUrl
+	}
+}
+
+// ProxyCreds option: credentials of the proxy in the form of username:password.
+func ProxyCreds(pcreds string) Option {
+	return func(client *Client) {
+		client.proxyCreds = pcreds
+	}
+}
+
+func MaxRetries(maxRetries int) Option {
+	return func(client *Client) {
+		client.maxRetries = maxRetries
+	}
+}
+
+func BackoffMinDelay(backoffMinDelay int) Option {
+	return func(client *Client) {
+		client.backoffMinDelay = backoffMinDelay
+	}
+}
+
+func BackoffMaxDelay(backoffMaxDelay int) Option {
+	return func(client *Client) {
+		client.backoffMaxDelay = backoffMaxDelay
+	}
+}
+
+func BackoffDelayFactor(backoffDelayFactor float64) Option {
+	return func(client *Client) {
+		client.backoffDelayFactor = backoffDelayFactor
+	}
+}
+
+// MaxConcurrentRequests option: limits the number of requests in flight at any time. Zero means no limit.
+func MaxConcurrentRequests(maxConcurrentRequests int) Option {
+	return func(client *Client) {
+		client.maxConcurrentRequests = maxConcurrentRequests
+	}
+}
+
+// RequestsPerSecond option: limits the rate of requests sent to the Hyperfabric service, including retries. Zero means no limit.
+func RequestsPerSecond(requestsPerSecond float64) Option {
+	return func(client *Client) {
+		client.requestsPerSecond = requestsPerSecond
+	}
+}
+
+// CacheGetRequests enables the caching of the responses of GET requests for the life of the client.
+// Concurrent identical GET requests are sent once and POST, PUT and DELETE requests invalidate the affected responses.
+func CacheGetRequests(cacheGetRequests bool) Option {
+	return func(client *Client) {
+		client.cacheGetRequests = cacheGetRequests
+	}
+}
+
+func AutoCommit(autoCommit bool) Option {
+	return func(client *Client) {
+		client.autoCommit = autoCommit
+	}
+}
+
+// Candidate option: commits the candidate configuration named candidate instead of DefaultCandidate, see DoAutoCommit.
+func Candidate(candidate string) Option {
+	return func(client *Client) {
+		client.candidate = candidate
+	}
+}
+
+// GetCandidate returns the name of the candidate configuration of the client, see Candidate, or DefaultCandidate
+// when none is set.
+func (c *Client) GetCandidate() string {
+	if c.candidate == "" {
+		return DefaultCandidate
+	}
+	return c.candidate
+}
+
+// AddChangedFabric registers the fabric identified by fabricId as changed, see DoAutoCommit.
+func (c *Client) AddChangedFabric(fabricId string) {
+	c.lockChangedFabric.Lock()
+	if c.changedFabrics == nil {
+		c.changedFabrics = map[string]bool{}
+	}
+	c.changedFabrics[fabricId] = true
+	c.lockChangedFabric.Unlock()
+}
+
+// DoAutoCommit commits the candidate configuration of the client, see Candidate, of every fabric changed through the
+// client when auto-commit is set. The changed fabrics are forgotten once committed.
+func (c *Client) DoAutoCommit() {
+	if c == nil {
+		return
+	}
+	// The changed fabrics are taken from the client, so the lock is not held during the commits.
+	c.lockChangedFabric.Lock()
+	changedFabrics := c.changedFabrics
+	if c.autoCommit {
+		c.changedFabrics = nil
+	}
+	c.lockChangedFabric.Unlock()
+	if len(changedFabrics) == 0 || !c.autoCommit {
+		return
+	}
+	log.Printf("[DEBUG] Start of the auto-committing process due to auto_commit (true) and change detected on %s.", c.baseURL.Redacted())
+	candidate := c.GetCandidate()
+	for fabricId := range changedFabrics {
+		log.Printf("[TRACE] Auto-committing candidate %s for fabric %s.", candidate, fabricId)
+		if err := c.Candidates.Commit(context.Background(), fabricId, candidate, "Terraform Auto-Commit"); err != nil {
+			log.Printf("[DEBUG] Error when committing candidate %s of Fabric %s, %s.", candidate, fabricId, err)
+		}
+	}
+
+	log.Printf("[DEBUG] End of the auto-committing process.")
+}
+
+// CommittedCandidate forgets the changes of the fabric identified by fabricId once the candidate configuration named
+// candidate was committed outside of DoAutoCommit, such as by the hyperfabric_fabric_commit resource, so that the
+// auto-commit does not commit them again. Commits of another candidate configuration than the one of the client are
+// ignored.
+func (c *Client) CommittedCandidate(fabricId, candidate string) {
+	if candidate != c.GetCandidate() {
+		return
+	}
+	c.lockChangedFabric.Lock()
+	delete(c.changedFabrics, fabricId)
+	c.lockChangedFabric.Unlock()
+}
+
+// HttpClient option: allows for caller to set 'httpClient' with 'Transport'.
+// When this option is set the proxy and TLS options are ignored.
+func HttpClient(httpcl *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = httpcl
+	}
+}
+
+func SkipLoggingPayload(skipLoggingPayload bool) Option {
+	return func(client *Client) {
+		client.skipLoggingPayload = skipLoggingPayload
+	}
+}
+
+func PreserveBaseUrlRef(preserveBaseUrlRef bool) Option {
+	return func(client *Client) {
+		client.preserveBaseUrlRef = preserveBaseUrlRef
+	}
+}
+
+func ReqTimeout(timeout uint32) Option {
+	return func(client *Client) {
+		client.reqTimeoutSet = true
+		client.reqTimeoutVal = timeout
+	}
+}
+
+func initClient(clientUrl, apiToken string, options ...Option) *Client {
+	var transport *http.Transport
+	baseUrl, err := url.Parse(clientUrl)
+	if err != nil {
+		// cannot move forward if url is undefined
+		log.Fatal(err)
+	}
+	client := &Client{
+		baseURL:  baseUrl,
+		apiToken: apiToken,
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	if client.httpClient == nil {
+		transport = client.newTransport()
+		client.httpClient = &http.Client{
+			Transport: transport,
+		}
+	}
+
+	var timeout time.Duration
+	if client.reqTimeoutSet {
+		timeout = time.Second * time.Duration(client.reqTimeoutVal)
+	} else {
+		timeout = time.Second * time.Duration(DefaultReqTimeoutVal)
+	}
+
+	client.httpClient.Timeout = timeout
+
+	if client.maxConcurrentRequests > 0 {
+		client.requestSlots = make(chan struct{}, client.maxConcurrentRequests)
+	}
+	if client.requestsPerSecond > 0 {
+		client.rateLimiter = newRateLimiter(client.requestsPerSecond)
+	}
+	if client.cacheGetRequests {
+		client.responseCache = newResponseCache(client.maxRequestDuration())
+	}
+	client.initServices()
+	return client
+}
+
+// GetClient returns a new client, see NewClient.
+//
+// Deprecated: GetClient no longer returns a singleton, use NewClient.
+func GetClient(clientUrl, apiToken string, options ...Option) *Client {
+	return NewClient(clientUrl, apiToken, options...)
+}
+
+// NewClient returns a new Instance of the client - allowing for simultaneous connections to the Hyperfabric service
+func NewClient(clientUrl, apiToken string, options ...Option) *Client {
+	// making sure it is the same client
+	_, err := url.Parse(clientUrl)
+	if err != nil {
+		// cannot move forward if url is undefined
+		log.Fatal(err)
+	}
+
+	// initClient always returns a new struct, so always create a new pointer to allow for
+	// multiple object instances
+	newClientImpl := initClient(clientUrl, apiToken, options...)
+
+	return newClientImpl
+}
+
+func (c *Client) MakeRestRequest(method string, path string, payloadContainer *gabs.Container, payloadByteArray []byte, authenticated bool) (*http.Request, error) {
+	return c.MakeRestRequestWithContext(context.Background(), method, path, payloadContainer, payloadByteArray, authenticated)
+}
+
+// MakeRestRequestWithContext builds a request bound to ctx, so that cancelling ctx aborts the request and any retries.
+func (c *Client) MakeRestRequestWithContext(ctx context.Context, method string, path string, payloadContainer *gabs.Container, payloadByteArray []byte, authenticated bool) (*http.Request, error) {
+
+	pathURL, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := url.Parse(c.baseURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if c.preserveBaseUrlRef {
+		// Default is false for preserveBaseUrlRef - matching original behavior to strip out baseURL
+		urlStr := url.String() + pathURL.String()
+		url, err = url.Parse(urlStr)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Original behavior to strip down baseURL
+		url = url.ResolveReference(pathURL)
+	}
+
+	var req *http.Request
+	tflog.SubsystemTrace(c.logContext(ctx), LogSubsystem, "Building HTTP request", map[string]interface{}{"method": method, "path": path, "url": url.String()})
+	if method == "GET" || method == "DELETE" {
+		req, err = http.NewRequestWithContext(ctx, method, url.String(), nil)
+	} else if payloadContainer != nil {
+		req, err = http.NewRequestWithContext(ctx, method, url.String(), bytes.NewBuffer((payloadContainer.Bytes())))
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, url.String(), bytes.NewBuffer(payloadByteArray))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if revisionId, ok := revisionFromContext(ctx); ok && (method == "PUT" || method == "DELETE") {
+		req.Header.Set("If-Match", revisionId)
+	}
+	// req.Header.Set("Cisco-FA-ShowEmpty", "true")
+
+	if authenticated {
+		req, err = c.InjectAuthenticationHeader(req, "")
+		if err != nil {
+			return req, err
+		}
+	}
+
+	return req, nil
+}
+
+func StrtoInt(s string, startIndex int, bitSize int) (int64, error) {
+	return strconv.ParseInt(s, startIndex, bitSize)
+}
+
+// DoWithContext executes req bound to ctx. See Do.
+func (c *Client) DoWithContext(ctx context.Context, req *http.Request) (*gabs.Container, *http.Response, error) {
+	return c.Do(req.WithContext(ctx))
+}
+
+// Do executes req with retries. The context of req is honored for the HTTP call and for the sleeps between retries.
+func (c *Client) Do(req *http.Request) (*gabs.Container, *http.Response, error) {
+	ctx := c.logContext(req.Context())
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Beginning Do method", map[string]interface{}{"method": req.Method, "path": req.URL.Path})
+	if c.transportErr != nil {
+		return nil, nil, c.transportErr
+	}
+
+	// retain the request body across multiple attempts
+	var body []byte
+	hasBody := req.Body != nil
+	if hasBody {
+		body, _ = io.ReadAll(req.Body)
+	}
+
+	reauthenticated := false
+	for attempts := 0; ; attempts++ {
+		logFields := map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempts,
+		}
+		if hasBody {
+			req.Body = io.NopCloser(bytes.NewBuffer(body))
+		}
+		if !c.skipLoggingPayload && len(body) > 0 {
+			logFields["payload"] = logPayload(body)
+		}
+		tflog.SubsystemTrace(ctx, LogSubsystem, "Sending HTTP request", logFields)
+
+		start := time.Now()
+		attemptCtx, span := c.startAttemptSpan(ctx, req, attempts)
+		resp, bodyBytes, err := c.send(attemptCtx, req)
+		endAttemptSpan(span, resp, bodyBytes, err)
+		duration := time.Since(start)
+		logFields["duration"] = duration.String()
+		if err == nil {
+			c.stats.recordAttempt(req.Method, pathTemplate(req.URL.Path), attempts, resp.StatusCode, duration)
+		} else if ctx.Err() == nil {
+			c.stats.recordAttempt(req.Method, pathTemplate(req.URL.Path), attempts, 0, duration)
+		}
+		delete(logFields, "payload")
+		if err != nil {
+			if ctx.Err() != nil {
+				tflog.SubsystemDebug(ctx, LogSubsystem, "HTTP request cancelled", withLogField(logFields, "error", ctx.Err()))
+				return nil, nil, newCancelledError(req, ctx.Err())
+			} else if strings.Contains(err.Error(), " tls: ") {
+				tflog.SubsystemError(ctx, LogSubsystem, "HTTP connection failed due to a TLS error", withLogField(logFields, "error", err))
+				return nil, nil, fmt.Errorf("failed to connect due to a TLS error. Verify that you are connecting to the correct Hyperfabric service.\nError message: %+v", err)
+			} else {
+				if ok := c.backoff(ctx, attempts, 0); !ok {
+					if ctx.Err() != nil {
+						return nil, nil, newCancelledError(req, ctx.Err())
+					}
+					tflog.SubsystemError(ctx, LogSubsystem, "HTTP connection failed", withLogField(logFields, "error", err))
+					return nil, nil, fmt.Errorf("failed to connect to the Hyperfabric service. Verify that you are connecting to the correct Hyperfabric service.\nError message: %+v", err)
+				} else {
+					tflog.SubsystemWarn(ctx, LogSubsystem, "HTTP connection failed, retrying", withLogField(logFields, "error", err))
+					continue
+				}
+			}
+		}
+
+		logFields["status"] = resp.StatusCode
+		if !c.skipLoggingPayload && len(bodyBytes) > 0 {
+			logFields["payload"] = logPayload(bodyBytes)
+		}
+		if resp.StatusCode == 204 {
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Received HTTP response", logFields)
+			return nil, resp, nil
+		} else if resp.StatusCode == 200 || resp.StatusCode == 201 {
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Received HTTP response", logFields)
+			obj, err := gabs.ParseJSON(bodyBytes)
+			if err != nil {
+				tflog.SubsystemError(ctx, LogSubsystem, "Parsing of the JSON response failed", withLogField(logFields, "error", err))
+
+				// If nginx is too busy or the page is not found, nginx might respond with an HTML doc instead of a JSON Response.
+				// In those cases, parse the HTML response for the message and return that to the user
+				// htmlErr := c.checkHtmlResp(bodyStr)
+				return nil, resp, fmt.Errorf("failed to parse JSON response with status code 200 and 201 from: %s. Verify that you are connecting to the correct Hyperfabric service.\nHTTP response status: %s\nMessage: %s", req.URL.String(), resp.Status, bodyBytes)
+			}
+			return obj, resp, nil
+		} else if resp.StatusCode == http.StatusUnauthorized && c.tokenSource != nil && !reauthenticated {
+			// The token expired or was revoked before its announced expiry, retry once with a new token
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Token rejected, retrying with a new token", logFields)
+			reauthenticated = true
+			c.tokenSource.Invalidate(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+			if _, err := c.InjectAuthenticationHeader(req, req.URL.Path); err != nil {
+				return nil, resp, err
+			}
+			// Mask the new token in the logs of the next attempts.
+			ctx = c.logContext(ctx)
+			continue
+		} else {
+			// Retries are driven by the HTTP status class, so that HTML error pages returned by a gateway
+			// in front of the Hyperfabric service are retried in the same way as JSON errors from the service.
+			retryable := isRetryableStatusCode(resp.StatusCode)
+			obj, err := gabs.ParseJSON(bodyBytes)
+			if err == nil {
+				if data, ok := obj.Data().(map[string]interface{}); ok {
+					restError := NewRestError(data)
+					if restError.ErrCode == "ERR_CODE_SERVICE_UNAVAILABLE" || restError.ErrCode == "ERR_CODE_TOO_MANY_REQUESTS" {
+						retryable = true
+					}
+					logFields["error_code"] = restError.ErrCode
+					if restError.TrackingId != "" {
+						logFields["tracking_id"] = restError.TrackingId
+					}
+				}
+			}
+			if ok := retryable && c.backoff(ctx, attempts, getRetryAfterDelay(resp)); !ok {
+				tflog.SubsystemDebug(ctx, LogSubsystem, "Received HTTP error response", logFields)
+				if ctx.Err() != nil {
+					return nil, resp, newCancelledError(req, ctx.Err())
+				}
+				if err != nil {
+					// If nginx is too busy or the page is not found, nginx might respond with an HTML doc instead of a JSON Response.
+					// In those cases, parse the HTML response for the message and return that to the user
+					// htmlErr := c.checkHtmlResp(bodyStr)
+					return nil, resp, fmt.Errorf("failed to parse JSON response from: %s. Verify that you are connecting to the correct Hyperfabric service.\nHTTP response status: %s\nMessage: %s", req.URL.String(), resp.Status, bodyBytes)
+				}
+				return obj, resp, nil
+			} else {
+				tflog.SubsystemWarn(ctx, LogSubsystem, "HTTP request failed, retrying", logFields)
+				continue
+			}
+		}
+	}
+}
+
+// send performs a single attempt of req within the concurrency and rate limits of the client
+// and returns the response with its body already read and closed.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return resp, bodyBytes, nil
+}
+
+// withLogField returns a copy of fields with key set to value.
+func withLogField(fields map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		copied[k] = v
+	}
+	copied[key] = value
+	return copied
+}
+
+// isRetryableStatusCode reports whether a response with statusCode indicates a transient condition
+// of the Hyperfabric service or of a gateway in front of it.
+func isRetryableStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// getRetryAfterDelay returns the delay requested by the Retry-After header of resp, which is either
+// a number of seconds or an HTTP date. Zero is returned when the header is absent or invalid.
+func getRetryAfterDelay(resp *http.Response) time.Duration {
+	retryAfter := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if retryAfter == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		if seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		return 0
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// maxRequestDuration returns the longest duration of a request with its retries: the timeout of every attempt and the
+// maximum delay between them.
+func (c *Client) maxRequestDuration() time.Duration {
+	maxDelay := time.Duration(DefaultBackoffMaxDelay) * time.Second
+	if c.backoffMaxDelay != 0 {
+		maxDelay = time.Duration(c.backoffMaxDelay) * time.Second
+	}
+	return time.Duration(c.maxRetries+1)*c.httpClient.Timeout + time.Duration(c.maxRetries)*maxDelay
+}
+
+// backoff sleeps before the next attempt and returns false when no further attempt should be made,
+// either because the retries are exhausted or because ctx was cancelled while sleeping.
+// A positive retryAfter, as requested by the service, replaces the exponential delay and is capped by the maximum delay.
+func (c *Client) backoff(ctx context.Context, attempts int, retryAfter time.Duration) bool {
+	if attempts >= c.maxRetries {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Retries exhausted", map[string]interface{}{"attempt": attempts, "max_retries": c.maxRetries})
+		return false
+	}
+
+	minDelay := time.Duration(DefaultBackoffMinDelay) * time.Second
+	if c.backoffMinDelay != 0 {
+		minDelay = time.Duration(c.backoffMinDelay) * time.Second
+	}
+
+	maxDelay := time.Duration(DefaultBackoffMaxDelay) * time.Second
+	if c.backoffMaxDelay != 0 {
+		maxDelay = time.Duration(c.backoffMaxDelay) * time.Second
+	}
+
+	factor := DefaultBackoffDelayFactor
+	if c.backoffDelayFactor != 0 {
+		factor = c.backoffDelayFactor
+	}
+
+	min := float64(minDelay)
+	backoff := min * math.Pow(factor, float64(attempts))
+	if backoff > float64(maxDelay) {
+		backoff = float64(maxDelay)
+	}
+	backoff = (rand.Float64()/2+0.5)*(backoff-min) + min
+	backoffDuration := time.Duration(backoff)
+	if retryAfter > 0 {
+		backoffDuration = retryAfter
+		if backoffDuration > maxDelay {
+			backoffDuration = maxDelay
+		}
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Waiting before the next attempt", map[string]interface{}{
+		"attempt":     attempts,
+		"delay":       backoffDuration.Round(time.Millisecond).String(),
+		"retry_after": retryAfter > 0,
+	})
+	start := time.Now()
+	defer func() {
+		c.stats.recordBackoff(time.Since(start))
+	}()
+	timer := time.NewTimer(backoffDuration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+	}
+	return true
+}
+
+type RestError struct {
+	Causes     []string
+	Critical   bool
+	ErrCode    string // int32
+	Field      string
+	Message    string
+	Notes      string
+	Status     float64
+	TrackingId string
+	Value      string
+}
+
+type DiagError struct {
+	Summary string
+	Detail  string
+	// Err is the error the diagnostic was built from, an *APIError for error responses of the Hyperfabric service.
+	Err error
+}
+
+func getDiagError(summary string, detail string) *DiagError {
+	return &DiagError{
+		Summary: summary,
+		Detail:  detail,
+	}
+}
+
+func (e RestError) ToString() string {
+	restErrorString := fmt.Sprintf(
+		"Status: %v Error Code: %s Message: %s Field: %s Value: %s Critical: %v Notes: %s",
+		e.Status,
+		e.ErrCode,
+		e.Message,
+		e.Field,
+		e.Value,
+		e.Critical,
+		e.Notes,
+	)
+	if len(e.Causes) > 0 {
+		restErrorString = fmt.Sprintf("%s Causes: %s", restErrorString, strings.Join(e.Causes, "; "))
+	}
+	if e.TrackingId != "" {
+		restErrorString = fmt.Sprintf("%s Tracking ID: %s", restErrorString, e.TrackingId)
+	}
+	return restErrorString
+}
+
+// func getEmptyRestError() RestError {
+// 	return RestError{
+// 		Causes:     make([]string, 0),
+// 		Critical:   false,
+// 		ErrCode:    ""
+// 		Field:      ""
+// 		Message:    basetypes.NewStringNull(),
+// 		Notes:      basetypes.NewStringNull(),
+//		Status:
+// 		TrackingId: basetypes.NewStringNull(),
+// 		Value:      basetypes.NewStringNull(),
+// 	}
+// }
+
+func NewRestError(data map[string]interface{}) RestError {
+	var restError RestError
+	restError.ErrCode = "NO_ERROR_CODE"
+	for attributeName, attributeValue := range data {
+		if attributeName == "causes" && attributeValue != nil {
+			newCauses := make([]string, 0)
+			for _, cause := range attributeValue.([]interface{}) {
+				newCauses = append(newCauses, fmt.Sprint(cause))
+			}
+			restError.Causes = newCauses
+		} else if attributeName == "critical" && attributeValue != nil {
+			restError.Critical = attributeValue.(bool)
+		} else if attributeName == "errCode" && attributeValue != nil {
+			restError.ErrCode = attributeValue.(string)
+		} else if attributeName == "field" && attributeValue != nil {
+			restError.Field = attributeValue.(string)
+		} else if attributeName == "message" && attributeValue != nil {
+			restError.Message = attributeValue.(string)
+		} else if attributeName == "notes" && attributeValue != nil {
+			restError.Notes = attributeValue.(string)
+		} else if attributeName == "status" && attributeValue != nil {
+			restError.Status = attributeValue.(float64)
+		} else if attributeName == "trackingId" && attributeValue != nil {
+			restError.TrackingId = attributeValue.(string)
+		} else if attributeName == "value" && attributeValue != nil {
+			restError.Value = attributeValue.(string)
+		}
+	}
+	return restError
+}
+
+// CancelledError is returned when the context of a request is cancelled or its deadline is exceeded
+// before the request or one of its retries completes.
+type CancelledError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+func newCancelledError(req *http.Request, err error) *CancelledError {
+	return &CancelledError{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Err:    err,
+	}
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("the %s request to %s was cancelled: %v", e.Method, e.URL, e.Err)
+}
+
+func (e *CancelledError) Unwrap() error {
+	return e.Err
+}
+
+func (c *Client) DoRestRequest(path, method string, payload *gabs.Container) (*gabs.Container, *DiagError) {
+	return c.DoRestRequestWithContext(context.Background(), path, method, payload)
+}
+
+// DoRestRequestWithContext builds, sends and retries a REST request bound to ctx.
+// Cancellation of ctx is reported as its own DiagError instead of a connection failure.
+// GET requests are served from the response cache when it is enabled, see CacheGetRequests.
+func (c *Client) DoRestRequestWithContext(ctx context.Context, path, method string, payload *gabs.Container) (*gabs.Container, *DiagError) {
+	ctx = c.logContext(ctx)
+	if c.responseCache == nil {
+		return c.doRestRequest(ctx, path, method, payload)
+	}
+	if strings.ToUpper(method) == "GET" {
+		return c.responseCache.get(ctx, path, func(fetchCtx context.Context) (*gabs.Container, *DiagError) {
+			return c.doRestRequest(fetchCtx, path, method, payload)
+		})
+	}
+	// Invalidate also when the request failed, since the Hyperfabric service may have applied it partially.
+	defer c.responseCache.invalidate(path)
+	return c.doRestRequest(ctx, path, method, payload)
+}
+
+func (c *Client) doRestRequest(ctx context.Context, path, method string, payload *gabs.Container) (*gabs.Container, *DiagError) {
+	restRequest, err := c.MakeRestRequestWithContext(ctx, method, path, payload, nil, true)
+	if err != nil {
+		errString := fmt.Sprintf("Error: %s. Please report this issue to the provider developers.", err)
+		if strings.HasPrefix(err.Error(), "An Hyperfabric API Bearer Token") || errors.Is(err, ErrAuth) {
+			errString = fmt.Sprintf("Error: %s.", err)
+		}
+		diagError := getDiagError(
+			fmt.Sprintf("Creation of %s REST request to %s failed", strings.ToUpper(method), path),
+			errString,
+		)
+		return nil, diagError
+	}
+	// c.lockRequest.Lock()
+	container, restResponse, err := c.Do(restRequest)
+	// c.lockRequest.Unlock()
+
+	var cancelledError *CancelledError
+	if errors.As(err, &cancelledError) {
+		diagError := getDiagError(
+			fmt.Sprintf("The %s REST request to %s was cancelled", strings.ToUpper(method), path),
+			fmt.Sprintf("The operation was interrupted before the Hyperfabric service responded: %v.", cancelledError.Err),
+		)
+		return nil, diagError
+	}
+
+	if restResponse != nil && container.Data() != nil && (restResponse.StatusCode != 200 && restResponse.StatusCode != 204) {
+		var restError RestError
+		if data, ok := container.Data().(map[string]interface{}); ok {
+			restError = NewRestError(data)
+		}
+
+		// Need error codes for:  Cannot create object, Cannot delete object
+		tflog.SubsystemDebug(c.logContext(ctx), LogSubsystem, "REST request failed", map[string]interface{}{
+			"method":      strings.ToUpper(method),
+			"path":        path,
+			"status":      restResponse.StatusCode,
+			"error_code":  restError.ErrCode,
+			"tracking_id": restError.TrackingId,
+			"error":       restError.ToString(),
+		})
+
+		if restResponse.StatusCode == 404 && (strings.ToLower(method) == "get" || strings.ToLower(method) == "delete") {
+			return nil, nil
+		} else {
+			apiError := newAPIError(strings.ToUpper(method), path, restResponse.StatusCode, restError)
+			detail := fmt.Sprintf("%s, err: %v. Please report this issue to the provider developers.", restError.ToString(), err)
+			// Errors caused by the configuration are for the user to fix.
+			if apiError.IsUserError() {
+				detail = fmt.Sprintf("%s.", restError.ToString())
+			}
+			diagError := getDiagError(
+				fmt.Sprintf("The %s REST request to %s failed with HTTP Status Code %d", strings.ToUpper(method), path, restResponse.StatusCode),
+				detail,
+			)
+			diagError.Err = apiError
+			return nil, diagError
+		}
+	} else if err != nil {
+		if restResponse == nil || !(restResponse.StatusCode == 404 && (strings.ToLower(method) == "get" || strings.ToLower(method) == "delete")) {
+			summary := fmt.Sprintf("The %s REST request to %s failed", strings.ToUpper(method), path)
+			if restResponse != nil {
+				summary = fmt.Sprintf("%s with HTTP Status Code %d", summary, restResponse.StatusCode)
+			}
+			diagError := getDiagError(
+				summary,
+				fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
+			)
+			diagError.Err = err
+			if restResponse != nil && restResponse.StatusCode != 200 && restResponse.StatusCode != 201 {
+				apiError := newAPIError(strings.ToUpper(method), path, restResponse.StatusCode, RestError{})
+				if apiError.IsUserError() {
+					diagError.Detail = fmt.Sprintf("Err: %s.", err)
+				}
+				diagError.Err = apiError
+			}
+			return nil, diagError
+		}
+		return nil, nil
+	}
+
+	return container, nil
+}
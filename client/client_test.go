@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDoRestRequestWithContextCancelledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"errCode": "ERR_CODE_SERVICE_UNAVAILABLE", "status": 503}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(5), BackoffMinDelay(30), BackoffMaxDelay(60))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, diagError := c.DoRestRequestWithContext(ctx, "/api/v1/fabrics", "GET", nil)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the request to return shortly after cancellation, took %v", elapsed)
+	}
+	if diagError == nil {
+		t.Fatal("expected a diagnostic for the cancelled request")
+	}
+	if !strings.Contains(diagError.Summary, "was cancelled") {
+		t.Errorf("expected a cancellation diagnostic, got %q", diagError.Summary)
+	}
+}
+
+func TestDoRestRequestWithContextCancelledInFlight(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := NewClient(server.URL, "token", MaxRetries(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, diagError := c.DoRestRequestWithContext(ctx, "/api/v1/fabrics", "GET", nil)
+	if diagError == nil {
+		t.Fatal("expected a diagnostic for the cancelled request")
+	}
+	if !strings.Contains(diagError.Summary, "was cancelled") {
+		t.Errorf("expected a cancellation diagnostic, got %q", diagError.Summary)
+	}
+}
+
+func TestDoRetriesHtmlGatewayErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html><body><h1>502 Bad Gateway</h1></body></html>"))
+			return
+		}
+		w.Write([]byte(`{"id": "fabric"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(2), BackoffMinDelay(30))
+
+	start := time.Now()
+	container, diagError := c.DoRestRequestWithContext(context.Background(), "/api/v1/fabrics/fabric", "GET", nil)
+	if diagError != nil {
+		t.Fatalf("unexpected error: %s %s", diagError.Summary, diagError.Detail)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if got := container.S("id").Data(); got != "fabric" {
+		t.Errorf("expected id 'fabric', got %v", got)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the Retry-After delay to replace the backoff delay, took %v", elapsed)
+	}
+}
+
+func TestDoRetryAfterCappedByBackoffMaxDelay(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(1), BackoffMinDelay(1), BackoffMaxDelay(1))
+
+	start := time.Now()
+	_, diagError := c.DoRestRequestWithContext(context.Background(), "/api/v1/fabrics", "GET", nil)
+	if diagError != nil {
+		t.Fatalf("unexpected error: %s %s", diagError.Summary, diagError.Detail)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the Retry-After delay to be capped by the maximum backoff delay, took %v", elapsed)
+	}
+}
+
+func TestDoDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errCode": "ERR_CODE_VALIDATION", "status": 400, "message": "invalid name"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(3))
+
+	_, diagError := c.DoRestRequestWithContext(context.Background(), "/api/v1/fabrics", "POST", nil)
+	if diagError == nil {
+		t.Fatal("expected a diagnostic for the failed request")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestGetRetryAfterDelay(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":        0,
+		"5":       5 * time.Second,
+		"-1":      0,
+		"invalid": 0,
+	}
+	for header, expected := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		if header != "" {
+			resp.Header.Set("Retry-After", header)
+		}
+		if got := getRetryAfterDelay(resp); got != expected {
+			t.Errorf("Retry-After %q: expected %v, got %v", header, expected, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if got := getRetryAfterDelay(resp); got <= 0 || got > time.Minute {
+		t.Errorf("expected an HTTP date Retry-After to yield a delay of up to one minute, got %v", got)
+	}
+}
@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Connection is a cable between two ports of the nodes of a fabric.
+type Connection struct {
+	Id           string              `json:"id,omitempty"`
+	FabricId     string              `json:"fabricId,omitempty"`
+	Description  *string             `json:"description,omitempty"`
+	Pluggable    *string             `json:"pluggable,omitempty"`
+	Local        *ConnectionEndpoint `json:"local,omitempty"`
+	Remote       *ConnectionEndpoint `json:"remote,omitempty"`
+	OsType       *string             `json:"osType,omitempty"`
+	Unrecognized *bool               `json:"unrecognized,omitempty"`
+}
+
+// ConnectionEndpoint is the local or remote side of a Connection.
+type ConnectionEndpoint struct {
+	NodeId   string `json:"nodeId"`
+	NodeName string `json:"nodeName,omitempty"`
+	PortName string `json:"portName"`
+}
+
+// ConnectionsService handles the /api/v1/fabrics/{fabricId}/connections endpoints.
+type ConnectionsService service
+
+// Get returns the connection identified by connectionId, or nil when it does not exist.
+func (s *ConnectionsService) Get(ctx context.Context, fabricId, connectionId string) (*Connection, error) {
+	return getObject[Connection](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/connections/%s", fabricId, connectionId))
+}
+
+// Create creates a connection in a fabric and returns it as returned by the Hyperfabric service.
+func (s *ConnectionsService) Create(ctx context.Context, fabricId string, connection *Connection) (*Connection, error) {
+	return createObject(ctx, s.client, "POST", fmt.Sprintf("/api/v1/fabrics/%s/connections", fabricId), "connections", connection)
+}
+
+// Update replaces the connection identified by connectionId.
+func (s *ConnectionsService) Update(ctx context.Context, fabricId, connectionId string, connection *Connection) (*Connection, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/connections/%s", fabricId, connectionId), connection)
+}
+
+// Delete deletes the connection identified by connectionId.
+func (s *ConnectionsService) Delete(ctx context.Context, fabricId, connectionId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/connections/%s", fabricId, connectionId))
+}
@@ -0,0 +1,147 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenCommandTimeout bounds the run time of the command of the TokenCommand option.
+const tokenCommandTimeout = time.Minute
+
+// TokenCommand option: authenticates the client with the token written to its standard output by command, run by
+// the shell of the system. The output is either the token, or a JSON object with the token in its token field and
+// optionally its expiry as an RFC 3339 time in its expires_at field. The token is cached and the command is run again
+// when the token is about to expire or rejected by the Hyperfabric service.
+func TokenCommand(command string) Option {
+	return func(client *Client) {
+		client.tokenSource = &tokenCommand{command: command}
+	}
+}
+
+// TokenFile option: authenticates the client with the token read from the file at path, in the format of the output
+// of the command of TokenCommand. The token is cached and the file is read again when the token is about to expire or
+// rejected by the Hyperfabric service, so that the file can be rotated while the client is in use.
+func TokenFile(path string) Option {
+	return func(client *Client) {
+		client.tokenSource = &tokenFile{path: path}
+	}
+}
+
+// tokenCommand is the tokenSource of the TokenCommand option.
+type tokenCommand struct {
+	command string
+	cached  cachedToken
+}
+
+func (s *tokenCommand) Token(ctx context.Context) (string, error) {
+	return s.cached.get(func() (string, time.Time, error) {
+		return s.run(ctx)
+	})
+}
+
+func (s *tokenCommand) Invalidate(token string) {
+	s.cached.invalidate(token)
+}
+
+// run runs the command and returns the token of its output.
+func (s *tokenCommand) run(ctx context.Context) (string, time.Time, error) {
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Running the token command")
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", s.command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", time.Time{}, fmt.Errorf("the token command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	token, expiry, err := parseToken(stdout.Bytes())
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("the output of the token command is invalid: %w", err)
+	}
+	return token, expiry, nil
+}
+
+// tokenFile is the tokenSource of the TokenFile option.
+type tokenFile struct {
+	path   string
+	cached cachedToken
+}
+
+func (s *tokenFile) Token(ctx context.Context) (string, error) {
+	return s.cached.get(func() (string, time.Time, error) {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Reading the token file", map[string]interface{}{"path": s.path})
+		content, err := os.ReadFile(s.path)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read the token file: %w", err)
+		}
+		token, expiry, err := parseToken(content)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("the content of the token file %s is invalid: %w", s.path, err)
+		}
+		return token, expiry, nil
+	})
+}
+
+func (s *tokenFile) Invalidate(token string) {
+	s.cached.invalidate(token)
+}
+
+// credentialOutput is the JSON output of a token command or content of a token file.
+type credentialOutput struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// parseToken returns the token and its expiry, zero when it does not expire, of the output of a token command or
+// the content of a token file.
+func parseToken(output []byte) (string, time.Time, error) {
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return "", time.Time{}, fmt.Errorf("no token")
+	}
+	if output[0] != '{' {
+		return string(output), time.Time{}, nil
+	}
+	var credential credentialOutput
+	if err := json.Unmarshal(output, &credential); err != nil {
+		return "", time.Time{}, err
+	}
+	token := credential.Token
+	if token == "" {
+		token = credential.AccessToken
+	}
+	if token == "" {
+		return "", time.Time{}, fmt.Errorf("no token in the token field")
+	}
+	var expiry time.Time
+	if credential.ExpiresAt != "" {
+		var err error
+		if expiry, err = time.Parse(time.RFC3339, credential.ExpiresAt); err != nil {
+			return "", time.Time{}, fmt.Errorf("expires_at is not an RFC 3339 time: %w", err)
+		}
+	}
+	return token, expiry, nil
+}
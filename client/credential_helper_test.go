@@ -0,0 +1,169 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newBearerServer returns a server rejecting the bearer tokens for which reject returns true, and the bearers of the
+// requests it received.
+func newBearerServer(t *testing.T, reject func(token string) bool) (*httptest.Server, func() []string) {
+	var lock sync.Mutex
+	var bearers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		bearers = append(bearers, r.Header.Get("Authorization"))
+		lock.Unlock()
+		if reject(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errCode": "ERR_CODE_UNAUTHENTICATED", "status": 401}`))
+			return
+		}
+		w.Write([]byte(`{"fabricId": "f1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, bearers...)
+	}
+}
+
+// newCountingTokenCommand returns a command printing token-1, token-2, ... on its consecutive runs, formatted with
+// format.
+func newCountingTokenCommand(t *testing.T, format string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("the token command of the test requires a POSIX shell")
+	}
+	runs := filepath.Join(t.TempDir(), "runs")
+	return fmt.Sprintf(`echo run >> %s; printf '%s' "token-$(wc -l < %s | tr -d ' ')"`, runs, format, runs)
+}
+
+func TestTokenCommand(t *testing.T) {
+	var lock sync.Mutex
+	rejected := ""
+	server, bearers := newBearerServer(t, func(token string) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return token == rejected
+	})
+	c := NewClient(server.URL, "", MaxRetries(0), TokenCommand(newCountingTokenCommand(t, "%s\n")))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, bearer := range bearers() {
+		if bearer != "Bearer token-1" {
+			t.Fatalf("expected the token of a single run of the command, got %q", bearers())
+		}
+	}
+
+	// token-1 is revoked
+	lock.Lock()
+	rejected = "token-1"
+	lock.Unlock()
+	if _, err := c.Fabrics.Get(context.Background(), "f2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := bearers(); len(got) != 7 || got[5] != "Bearer token-1" || got[6] != "Bearer token-2" {
+		t.Errorf("expected the command to be run again after a 401, got %q", got)
+	}
+}
+
+func TestTokenCommandExpiry(t *testing.T) {
+	server, bearers := newBearerServer(t, func(string) bool { return false })
+	expiresAt := time.Now().Add(tokenExpiryDelta / 2).Format(time.RFC3339)
+	c := NewClient(server.URL, "", MaxRetries(0), TokenCommand(newCountingTokenCommand(t, `{"token": "%s", "expires_at": "`+expiresAt+`"}`)))
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := bearers(); len(got) != 2 || got[1] != "Bearer token-2" {
+		t.Errorf("expected the command to be run again for an expiring token, got %q", got)
+	}
+}
+
+func TestTokenCommandFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the token command of the test requires a POSIX shell")
+	}
+	server, bearers := newBearerServer(t, func(string) bool { return false })
+	c := NewClient(server.URL, "", MaxRetries(0), TokenCommand("echo 'not logged in' >&2; exit 3"))
+
+	if _, err := c.Fabrics.Get(context.Background(), "f1"); err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("expected the error of the command, got %v", err)
+	}
+	if len(bearers()) != 0 {
+		t.Errorf("expected no request without a token, got %q", bearers())
+	}
+}
+
+func TestTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("token-1\n"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server, bearers := newBearerServer(t, func(token string) bool { return token == "token-1" })
+	c := NewClient(server.URL, "", MaxRetries(0), TokenFile(path))
+
+	if _, err := c.Fabrics.Get(context.Background(), "f1"); err == nil {
+		t.Fatal("expected the rejection of the token to be returned")
+	}
+	// the token file is rotated
+	if err := os.WriteFile(path, []byte(`{"token": "token-2"}`), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := bearers(); len(got) != 4 || got[3] != "Bearer token-2" {
+		t.Errorf("expected the token file to be read again after a 401, got %q", got)
+	}
+}
+
+func TestParseToken(t *testing.T) {
+	tests := []struct {
+		output string
+		token  string
+		expiry string
+		valid  bool
+	}{
+		{"token-1\n", "token-1", "", true},
+		{`{"token": "token-1", "expires_at": "2030-01-02T03:04:05Z"}`, "token-1", "2030-01-02T03:04:05Z", true},
+		{`{"access_token": "token-1"}`, "token-1", "", true},
+		{`{"expires_at": "2030-01-02T03:04:05Z"}`, "", "", false},
+		{`{"token": "token-1", "expires_at": "tomorrow"}`, "", "", false},
+		{"  \n", "", "", false},
+	}
+	for _, test := range tests {
+		token, expiry, err := parseToken([]byte(test.output))
+		if (err == nil) != test.valid || token != test.token || (test.expiry != "" && expiry.Format(time.RFC3339) != test.expiry) {
+			t.Errorf("parseToken(%q) = %q, %v, %v", test.output, token, expiry, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+)
+
+// Device is a physical device of the Hyperfabric organization, which can be bound to a node.
+type Device struct {
+	DeviceId     string   `json:"deviceId,omitempty"`
+	FabricId     *string  `json:"fabricId,omitempty"`
+	ModelName    *string  `json:"modelName,omitempty"`
+	NodeId       *string  `json:"nodeId,omitempty"`
+	OsType       *string  `json:"osType,omitempty"`
+	RackId       *string  `json:"rackId,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	SerialNumber *string  `json:"serialNumber,omitempty"`
+}
+
+// DevicesService handles the /api/v1/devices endpoints.
+type DevicesService service
+
+// List returns the devices of the Hyperfabric organization.
+func (s *DevicesService) List(ctx context.Context) ([]Device, error) {
+	return listObjects[Device](ctx, s.client, "/api/v1/devices", "devices")
+}
+
+// Iter returns an iterator over the devices of the Hyperfabric organization, requesting them page by page.
+func (s *DevicesService) Iter(opts *ListOptions) *Iterator[Device] {
+	return newIterator[Device](s.client, "/api/v1/devices", "devices", opts)
+}
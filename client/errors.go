@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kinds of the errors returned by the Hyperfabric service, use errors.Is to match an *APIError against them.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrAuth        = errors.New("authentication or authorization failed")
+	ErrRateLimited = errors.New("rate limited")
+	ErrServer      = errors.New("server error")
+)
+
+// APIError is an error response of the Hyperfabric service. It is wrapped by the DiagError returned for the request.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// RestError is the parsed body of the response, it is empty when the body was not a JSON error.
+	RestError RestError
+}
+
+func newAPIError(method, path string, statusCode int, restError RestError) *APIError {
+	return &APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: statusCode,
+		RestError:  restError,
+	}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("the %s REST request to %s failed with HTTP Status Code %d, %s", e.Method, e.Path, e.StatusCode, e.RestError.ToString())
+}
+
+// Kind returns the kind of the error, one of the Err variables of this package, or nil for an unclassified status code.
+func (e *APIError) Kind() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed:
+		return ErrConflict
+	case e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrAuth
+	case e.StatusCode == http.StatusTooManyRequests || e.RestError.ErrCode == "ERR_CODE_TOO_MANY_REQUESTS":
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// Is reports whether the kind of the error is target.
+func (e *APIError) Is(target error) bool {
+	kind := e.Kind()
+	return kind != nil && kind == target
+}
+
+// IsUserError reports whether the error is caused by the request rather than by the Hyperfabric service,
+// such as an invalid attribute value or a missing permission.
+func (e *APIError) IsUserError() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500 && e.Kind() != ErrRateLimited
+}
+
+// Error makes DiagError usable as an error returned by the typed services.
+func (e *DiagError) Error() string {
+	if e.Detail == "" {
+		return e.Summary
+	}
+	return fmt.Sprintf("%s: %s", e.Summary, e.Detail)
+}
+
+// Unwrap returns the error wrapped by the DiagError, such as an *APIError.
+func (e *DiagError) Unwrap() error {
+	return e.Err
+}
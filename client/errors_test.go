@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorKind(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		kind       error
+		userError  bool
+	}{
+		{http.StatusBadRequest, ErrValidation, true},
+		{http.StatusUnprocessableEntity, ErrValidation, true},
+		{http.StatusUnauthorized, ErrAuth, true},
+		{http.StatusForbidden, ErrAuth, true},
+		{http.StatusNotFound, ErrNotFound, true},
+		{http.StatusConflict, ErrConflict, true},
+		{http.StatusPreconditionFailed, ErrConflict, true},
+		{http.StatusTooManyRequests, ErrRateLimited, false},
+		{http.StatusInternalServerError, ErrServer, false},
+		{http.StatusServiceUnavailable, ErrServer, false},
+	}
+	for _, test := range tests {
+		apiError := newAPIError("PUT", "/api/v1/fabrics/f1", test.statusCode, RestError{})
+		if !errors.Is(apiError, test.kind) {
+			t.Errorf("expected status code %d to be %v, got %v", test.statusCode, test.kind, apiError.Kind())
+		}
+		if apiError.IsUserError() != test.userError {
+			t.Errorf("expected IsUserError of status code %d to be %v", test.statusCode, test.userError)
+		}
+	}
+}
+
+func TestDoRestRequestReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errCode": "ERR_CODE_BAD_REQUEST", "status": 400, "message": "invalid name", "field": "fabrics[0].name", "causes": ["too long"], "trackingId": "track-1"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	_, err := c.Fabrics.Create(context.Background(), &Fabric{Name: String("fabric1")})
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("expected an *APIError, got %v", err)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error, got %v", apiError.Kind())
+	}
+	if apiError.RestError.Field != "fabrics[0].name" || apiError.RestError.TrackingId != "track-1" {
+		t.Errorf("unexpected rest error %+v", apiError.RestError)
+	}
+	var diagError *DiagError
+	errors.As(err, &diagError)
+	if strings.Contains(diagError.Detail, "report this issue") {
+		t.Errorf("expected no request to report a validation error, got %q", diagError.Detail)
+	}
+	if !strings.Contains(diagError.Detail, "Causes: too long") || !strings.Contains(diagError.Detail, "Tracking ID: track-1") {
+		t.Errorf("expected the causes and tracking ID in the detail, got %q", diagError.Detail)
+	}
+}
+
+func TestDoRestRequestServerErrorAsksForReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"errCode": "ERR_CODE_INTERNAL", "status": 500}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	_, err := c.Fabrics.Get(context.Background(), "f1")
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("expected a server error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "report this issue") {
+		t.Errorf("expected a request to report the server error, got %q", err.Error())
+	}
+}
@@ -0,0 +1,56 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Fabric is a Hyperfabric fabric.
+type Fabric struct {
+	FabricId    string       `json:"fabricId,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Topology    *string      `json:"topology,omitempty"`
+	Location    *string      `json:"location,omitempty"`
+	Address     *string      `json:"address,omitempty"`
+	City        *string      `json:"city,omitempty"`
+	Country     *string      `json:"country,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+	Labels      []string     `json:"labels"`
+	Annotations []Annotation `json:"annotations"`
+}
+
+// MarshalJSON omits the nil lists of the fabric from its JSON payload, its empty lists are sent to clear them.
+func (f Fabric) MarshalJSON() ([]byte, error) {
+	type fabric Fabric
+	return marshalWithoutNilLists(fabric(f))
+}
+
+// FabricsService handles the /api/v1/fabrics endpoints.
+type FabricsService service
+
+// Get returns the fabric identified by its id or name, or nil when it does not exist.
+func (s *FabricsService) Get(ctx context.Context, fabricId string) (*Fabric, error) {
+	return getObject[Fabric](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s", fabricId))
+}
+
+// Create creates a fabric and returns it as returned by the Hyperfabric service.
+func (s *FabricsService) Create(ctx context.Context, fabric *Fabric) (*Fabric, error) {
+	return createObject(ctx, s.client, "POST", "/api/v1/fabrics", "fabrics", fabric)
+}
+
+// Update replaces the fabric identified by fabricId.
+func (s *FabricsService) Update(ctx context.Context, fabricId string, fabric *Fabric) (*Fabric, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s", fabricId), fabric)
+}
+
+// Delete deletes the fabric identified by fabricId.
+func (s *FabricsService) Delete(ctx context.Context, fabricId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s", fabricId))
+}
@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by all requests of a client.
+// The bucket refills at rate tokens per second and holds at most burst tokens.
+type rateLimiter struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	burst := math.Max(1, math.Ceil(requestsPerSecond))
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.lock.Lock()
+		now := time.Now()
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.lock.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.lock.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// acquireRequestSlot waits for the rate limiter and for a free slot of the in-flight semaphore, when configured.
+// The returned function releases the slot and must be called once the response has been consumed.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requestSlots != nil {
+		select {
+		case c.requestSlots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if c.requestSlots != nil {
+			<-c.requestSlots
+		}
+	}
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, diagError := c.DoRestRequestWithContext(context.Background(), "/api/v1/fabrics", "GET", nil); diagError != nil {
+				t.Errorf("unexpected error: %s %s", diagError.Summary, diagError.Detail)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 requests in flight, observed %d", maxInFlight)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := newRateLimiter(10)
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// The first 10 tokens are available immediately, the next 5 are refilled at 10 per second.
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected the rate limiter to delay requests beyond the burst, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter = newRateLimiter(0.001)
+	limiter.Wait(ctx)
+	if err := limiter.Wait(ctx); err == nil {
+		t.Error("expected an error when waiting with a cancelled context")
+	}
+}
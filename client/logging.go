@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem of the logs of the client. Its level is set with the
+// TF_LOG_PROVIDER_HYPERFABRIC_CLIENT environment variable and defaults to the level of the provider logs.
+const LogSubsystem = "hyperfabric_client"
+
+// redactedValue replaces the values of the sensitive headers and JSON keys in the logs.
+const redactedValue = "***"
+
+// sensitiveKeys are the lowercase names of the headers, log fields and JSON keys of the payloads whose values are
+// never logged.
+var sensitiveKeys = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"token":               true,
+	"accesstoken":         true,
+	"access_token":        true,
+	"refreshtoken":        true,
+	"refresh_token":       true,
+	"idtoken":             true,
+	"password":            true,
+	"proxypassword":       true,
+	"proxy_password":      true,
+	"secret":              true,
+	"clientsecret":        true,
+	"client_secret":       true,
+	"privatekey":          true,
+	"private_key":         true,
+	"apikey":              true,
+}
+
+type logContextKey struct{}
+
+// logContextValue is the value of logContextKey in the contexts returned by logContext, secrets is the number of
+// secrets of the client masked in the context.
+type logContextValue struct {
+	client  *Client
+	secrets int
+}
+
+// logContext returns ctx with the log subsystem of the client, which masks the credentials of the client and the
+// values of the sensitive log fields. The tokens obtained by the client after ctx was returned are masked in the
+// context returned for it again.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	secrets := c.secrets()
+	if value, ok := ctx.Value(logContextKey{}).(logContextValue); ok && value.client == c {
+		if value.secrets == len(secrets) {
+			return ctx
+		}
+		ctx = maskSecrets(ctx, secrets[value.secrets:])
+		return context.WithValue(ctx, logContextKey{}, logContextValue{c, len(secrets)})
+	}
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_HYPERFABRIC_CLIENT"), tflog.WithRootFields())
+	keys := make([]string, 0, len(sensitiveKeys))
+	for key := range sensitiveKeys {
+		keys = append(keys, key)
+	}
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, keys...)
+	ctx = maskSecrets(ctx, secrets)
+	return context.WithValue(ctx, logContextKey{}, logContextValue{c, len(secrets)})
+}
+
+func maskSecrets(ctx context.Context, secrets []string) context.Context {
+	for _, secret := range secrets {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, secret)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, LogSubsystem, secret)
+	}
+	return ctx
+}
+
+// secrets returns the credentials of the client, which are masked wherever they appear in the logs: its static
+// credentials followed by the tokens obtained from its token source, in the order they were obtained.
+func (c *Client) secrets() []string {
+	var secrets []string
+	for _, secret := range []string{c.apiToken, c.clientSecret, c.privatekey} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if _, password, found := strings.Cut(c.proxyCreds, ":"); found && password != "" {
+		secrets = append(secrets, password)
+	}
+	c.lockTokens.Lock()
+	defer c.lockTokens.Unlock()
+	return append(secrets, c.tokens...)
+}
+
+// addToken registers a token obtained from the token source of the client, such as an OAuth2 token or the output of
+// the token command, so that it is masked in the logs.
+func (c *Client) addToken(token string) {
+	c.lockTokens.Lock()
+	defer c.lockTokens.Unlock()
+	for _, known := range c.tokens {
+		if known == token {
+			return
+		}
+	}
+	c.tokens = append(c.tokens, token)
+}
+
+// logPayload returns body for the logs, with the values of the sensitive keys of a JSON body redacted. Bodies that are
+// not JSON, such as the HTML error pages of a gateway, are returned unchanged.
+func logPayload(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redactedValue
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if sensitiveKeys[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLogsAreStructuredAndRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errCode": "ERR_CODE_BAD_REQUEST", "status": 400, "trackingId": "track-1", "token": "echoed-secret-token"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	t.Setenv("TF_LOG_PROVIDER_HYPERFABRIC_CLIENT", "TRACE")
+
+	c := NewClient(server.URL, "secret-token", MaxRetries(0))
+	_, err := c.ManagementPorts.Update(ctx, "f1", "n1", "m1", &ManagementPort{Name: String("eth0"), ProxyPassword: String("proxy-secret")})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	logs := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var response map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Received HTTP error response" {
+			response = entry
+		}
+	}
+	if response == nil {
+		t.Fatalf("expected a log of the error response, got %s", logs)
+	}
+	if response["@module"] != "provider."+LogSubsystem || response["method"] != "PUT" || response["status"] != float64(400) || response["tracking_id"] != "track-1" {
+		t.Errorf("expected the structured fields of the response, got %v", response)
+	}
+	for _, secret := range []string{"secret-token", "proxy-secret"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("expected %s to be redacted from the logs, got %s", secret, logs)
+		}
+	}
+	if !strings.Contains(logs, `\"name\":\"eth0\"`) {
+		t.Errorf("expected the payload in the logs, got %s", logs)
+	}
+}
+
+func TestLogsMaskTokensOfTokenSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The error message echoes the token of the request, outside of any sensitive key.
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errCode": "ERR_CODE_BAD_REQUEST", "status": 400, "errMessage": "invalid request with ` + strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") + `"}`))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("file-secret-token\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	t.Setenv("TF_LOG_PROVIDER_HYPERFABRIC_CLIENT", "TRACE")
+	c := NewClient(server.URL, "", MaxRetries(0), TokenFile(path))
+	if _, err := c.Fabrics.Get(ctx, "f1"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	logs := output.String()
+	if !strings.Contains(logs, "invalid request with") {
+		t.Fatalf("expected a log of the error response, got %s", logs)
+	}
+	if strings.Contains(logs, "file-secret-token") {
+		t.Errorf("expected the token of the token file to be redacted from the logs, got %s", logs)
+	}
+}
+
+func TestSkipLoggingPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fabricId": "f1", "name": "fabric1"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	t.Setenv("TF_LOG_PROVIDER_HYPERFABRIC_CLIENT", "TRACE")
+
+	c := NewClient(server.URL, "token", MaxRetries(0), SkipLoggingPayload(true))
+	if _, err := c.Fabrics.Update(ctx, "f1", &Fabric{Name: String("fabric1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output.String(), "fabric1") || !strings.Contains(output.String(), "Received HTTP response") {
+		t.Errorf("expected the requests without their payloads in the logs, got %s", output.String())
+	}
+}
+
+func TestLogPayload(t *testing.T) {
+	payload := logPayload([]byte(`{"ports": [{"name": "eth0", "proxyPassword": "secret"}], "Token": "secret"}`))
+	if strings.Contains(payload, "secret") || !strings.Contains(payload, "eth0") {
+		t.Errorf("expected the sensitive values to be redacted, got %s", payload)
+	}
+	if payload := logPayload([]byte("<html>Bad Gateway</html>")); payload != "<html>Bad Gateway</html>" {
+		t.Errorf("expected a body that is not JSON unchanged, got %s", payload)
+	}
+}
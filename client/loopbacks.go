@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Loopback is a loopback interface of a node.
+type Loopback struct {
+	Id          string       `json:"id,omitempty"`
+	FabricId    string       `json:"fabricId,omitempty"`
+	NodeId      string       `json:"nodeId,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Enabled     *bool        `json:"enabled,omitempty"`
+	Ipv4Address *string      `json:"ipv4Address,omitempty"`
+	Ipv6Address *string      `json:"ipv6Address,omitempty"`
+	VrfId       *string      `json:"vrfId,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+	Labels      []string     `json:"labels"`
+	Annotations []Annotation `json:"annotations"`
+}
+
+// MarshalJSON omits the nil lists of the loopback from its JSON payload, its empty lists are sent to clear them.
+func (l Loopback) MarshalJSON() ([]byte, error) {
+	type loopback Loopback
+	return marshalWithoutNilLists(loopback(l))
+}
+
+// LoopbacksService handles the /api/v1/fabrics/{fabricId}/nodes/{nodeId}/loopbacks endpoints.
+type LoopbacksService service
+
+// Get returns the loopback identified by its id or name, or nil when it does not exist.
+func (s *LoopbacksService) Get(ctx context.Context, fabricId, nodeId, loopbackId string) (*Loopback, error) {
+	return getObject[Loopback](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/loopbacks/%s", fabricId, nodeId, loopbackId))
+}
+
+// Create creates a loopback on a node and returns it as returned by the Hyperfabric service.
+func (s *LoopbacksService) Create(ctx context.Context, fabricId, nodeId string, loopback *Loopback) (*Loopback, error) {
+	return createObject(ctx, s.client, "POST", fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/loopbacks", fabricId, nodeId), "loopbacks", loopback)
+}
+
+// Update replaces the loopback identified by loopbackId.
+func (s *LoopbacksService) Update(ctx context.Context, fabricId, nodeId, loopbackId string, loopback *Loopback) (*Loopback, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/loopbacks/%s", fabricId, nodeId, loopbackId), loopback)
+}
+
+// Delete deletes the loopback identified by loopbackId.
+func (s *LoopbacksService) Delete(ctx context.Context, fabricId, nodeId, loopbackId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/loopbacks/%s", fabricId, nodeId, loopbackId))
+}
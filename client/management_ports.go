@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ManagementPort is the management port of a node.
+// ProxyPassword is only sent to the Hyperfabric service, together with SetProxyPassword, and is never returned.
+type ManagementPort struct {
+	Id                string    `json:"id,omitempty"`
+	Name              *string   `json:"name,omitempty"`
+	Description       *string   `json:"description,omitempty"`
+	Enabled           *bool     `json:"enabled,omitempty"`
+	CloudUrls         []string  `json:"cloudUrls"`
+	Ipv4ConfigType    *string   `json:"ipv4ConfigType,omitempty"`
+	Ipv4Address       *string   `json:"ipv4Address,omitempty"`
+	Ipv4Gateway       *string   `json:"ipv4Gateway,omitempty"`
+	Ipv6ConfigType    *string   `json:"ipv6ConfigType,omitempty"`
+	Ipv6Address       *string   `json:"ipv6Address,omitempty"`
+	Ipv6Gateway       *string   `json:"ipv6Gateway,omitempty"`
+	DnsAddresses      []string  `json:"dnsAddresses"`
+	NtpAddresses      []string  `json:"ntpAddresses"`
+	NoProxy           []string  `json:"noProxy"`
+	ProxyAddress      *string   `json:"proxyAddress,omitempty"`
+	ProxyCredentialId *string   `json:"proxyCredentialId,omitempty"`
+	ProxyUsername     *string   `json:"proxyUsername,omitempty"`
+	ProxyPassword     *string   `json:"proxyPassword,omitempty"`
+	SetProxyPassword  *bool     `json:"setProxyPassword,omitempty"`
+	ConnectedState    *string   `json:"connectedState,omitempty"`
+	ConfigOrigin      *string   `json:"configOrigin,omitempty"`
+	Metadata          *Metadata `json:"metadata,omitempty"`
+}
+
+// MarshalJSON omits the nil lists of the management port from its JSON payload, its empty lists are sent to clear them.
+func (m ManagementPort) MarshalJSON() ([]byte, error) {
+	type managementPort ManagementPort
+	return marshalWithoutNilLists(managementPort(m))
+}
+
+// ManagementPortsService handles the /api/v1/fabrics/{fabricId}/nodes/{nodeId}/managementPorts endpoints.
+type ManagementPortsService service
+
+// List returns the management ports of a node.
+func (s *ManagementPortsService) List(ctx context.Context, fabricId, nodeId string) ([]ManagementPort, error) {
+	return listObjects[ManagementPort](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/managementPorts", fabricId, nodeId), "ports")
+}
+
+// Iter returns an iterator over the management ports of a node, requesting them page by page.
+func (s *ManagementPortsService) Iter(fabricId, nodeId string, opts *ListOptions) *Iterator[ManagementPort] {
+	return newIterator[ManagementPort](s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/managementPorts", fabricId, nodeId), "ports", opts)
+}
+
+// Create configures the management port of a node and returns it as returned by the Hyperfabric service.
+func (s *ManagementPortsService) Create(ctx context.Context, fabricId, nodeId string, managementPort *ManagementPort) (*ManagementPort, error) {
+	return createObject(ctx, s.client, "POST", fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/managementPorts", fabricId, nodeId), "ports", managementPort)
+}
+
+// Update replaces the management port identified by managementPortId.
+func (s *ManagementPortsService) Update(ctx context.Context, fabricId, nodeId, managementPortId string, managementPort *ManagementPort) (*ManagementPort, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/managementPorts/%s", fabricId, nodeId, managementPortId), managementPort)
+}
@@ -0,0 +1,47 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+// The API types below use pointers for optional scalar fields and nil slices for absent lists,
+// so that a field missing from a response can be told apart from a field set to its zero value.
+
+// Metadata holds the bookkeeping information the Hyperfabric service stores with every object.
+type Metadata struct {
+	CreatedAt  string `json:"createdAt,omitempty"`
+	CreatedBy  string `json:"createdBy,omitempty"`
+	ModifiedAt string `json:"modifiedAt,omitempty"`
+	ModifiedBy string `json:"modifiedBy,omitempty"`
+	RevisionId string `json:"revisionId,omitempty"`
+}
+
+// Annotation stores user-defined data on an object.
+type Annotation struct {
+	DataType string `json:"dataType"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+}
+
+// Member assigns a VLAN, or untagged traffic, of a port to a VNI.
+type Member struct {
+	Port     *MemberPort `json:"port,omitempty"`
+	VlanId   *float64    `json:"vlanId,omitempty"`
+	Untagged bool        `json:"untagged,omitempty"`
+}
+
+// MemberPort identifies the port of a Member, "*" matches all ports or all nodes.
+type MemberPort struct {
+	PortName *string `json:"portName,omitempty"`
+	NodeId   *string `json:"nodeId,omitempty"`
+	NodeName *string `json:"nodeName,omitempty"`
+}
+
+// Svi is the SVI / Distributed GW of a VNI.
+type Svi struct {
+	Enabled       *bool    `json:"enabled,omitempty"`
+	Ipv4Addresses []string `json:"ipv4Addresses"`
+	Ipv6Addresses []string `json:"ipv6Addresses"`
+}
@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Node is a node, such as a leaf or spine switch, of a fabric.
+type Node struct {
+	NodeId       string       `json:"nodeId,omitempty"`
+	FabricId     string       `json:"fabricId,omitempty"`
+	Name         *string      `json:"name,omitempty"`
+	Description  *string      `json:"description,omitempty"`
+	Enabled      *bool        `json:"enabled,omitempty"`
+	Location     *string      `json:"location,omitempty"`
+	ModelName    *string      `json:"modelName,omitempty"`
+	SerialNumber *string      `json:"serialNumber,omitempty"`
+	DeviceId     *string      `json:"deviceId,omitempty"`
+	Roles        []string     `json:"roles"`
+	Metadata     *Metadata    `json:"metadata,omitempty"`
+	Labels       []string     `json:"labels"`
+	Annotations  []Annotation `json:"annotations"`
+}
+
+// MarshalJSON omits the nil lists of the node from its JSON payload, its empty lists are sent to clear them.
+func (n Node) MarshalJSON() ([]byte, error) {
+	type node Node
+	return marshalWithoutNilLists(node(n))
+}
+
+// NodesService handles the /api/v1/fabrics/{fabricId}/nodes endpoints.
+type NodesService service
+
+// Get returns the node identified by its id or name, or nil when it does not exist.
+func (s *NodesService) Get(ctx context.Context, fabricId, nodeId string) (*Node, error) {
+	return getObject[Node](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s", fabricId, nodeId))
+}
+
+// Create creates a node in a fabric and returns it as returned by the Hyperfabric service.
+func (s *NodesService) Create(ctx context.Context, fabricId string, node *Node) (*Node, error) {
+	return createObject(ctx, s.client, "POST", fmt.Sprintf("/api/v1/fabrics/%s/nodes", fabricId), "nodes", node)
+}
+
+// Update replaces the node identified by nodeId.
+func (s *NodesService) Update(ctx context.Context, fabricId, nodeId string, node *Node) (*Node, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s", fabricId, nodeId), node)
+}
+
+// Delete deletes the node identified by nodeId.
+func (s *NodesService) Delete(ctx context.Context, fabricId, nodeId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s", fabricId, nodeId))
+}
+
+// BindDevice binds the device identified by deviceId to the node.
+func (s *NodesService) BindDevice(ctx context.Context, fabricId, nodeId, deviceId string) error {
+	_, err := s.client.doJSON(ctx, "PUT", fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/devices/%s", fabricId, nodeId, deviceId), nil, nil)
+	return err
+}
+
+// UnbindDevice unbinds the device bound to the node, if any.
+func (s *NodesService) UnbindDevice(ctx context.Context, fabricId, nodeId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/devices", fabricId, nodeId))
+}
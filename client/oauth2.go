@@ -0,0 +1,160 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenExpiryDelta is the time before the expiry of a token from which it is refreshed, so that a request is not
+// sent with a token expiring in flight.
+const tokenExpiryDelta = 30 * time.Second
+
+// tokenSource provides the bearer tokens of the requests of the client when it has no static API token.
+type tokenSource interface {
+	// Token returns the cached token, or a new token when none is cached or the cached one is expiring.
+	Token(ctx context.Context) (string, error)
+	// Invalidate forgets token when the Hyperfabric service rejected it, so that the next call to Token returns a new one.
+	Invalidate(token string)
+}
+
+// cachedToken caches a token of a tokenSource until its expiry. Concurrent requests for an expired token wait for
+// a single refresh.
+type cachedToken struct {
+	lock   sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// get returns the cached token, or the token returned by refresh when none is cached or the cached one is expiring.
+// refresh returns the token and its expiry, zero when it does not expire.
+func (t *cachedToken) get(refresh func() (string, time.Time, error)) (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.token != "" && (t.expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.expiry)) {
+		return t.token, nil
+	}
+	token, expiry, err := refresh()
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expiry = token, expiry
+	return token, nil
+}
+
+func (t *cachedToken) invalidate(token string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// OAuth2ClientCredentials option: authenticates the client with the tokens issued by the token endpoint at tokenUrl
+// for the OAuth2 client credentials grant of clientId and clientSecret, with the optional scopes. The tokens are cached
+// and refreshed when they are about to expire or rejected by the Hyperfabric service.
+func OAuth2ClientCredentials(clientId, clientSecret, tokenUrl string, scopes []string) Option {
+	return func(client *Client) {
+		client.clientSecret = clientSecret
+		client.tokenSource = &clientCredentials{
+			client:       client,
+			clientId:     clientId,
+			clientSecret: clientSecret,
+			tokenUrl:     tokenUrl,
+			scopes:       scopes,
+		}
+	}
+}
+
+// clientCredentials is the tokenSource of the OAuth2 client credentials grant, see RFC 6749 section 4.4.
+type clientCredentials struct {
+	client       *Client
+	clientId     string
+	clientSecret string
+	tokenUrl     string
+	scopes       []string
+	cached       cachedToken
+}
+
+// tokenResponse is the successful response of a token endpoint, see RFC 6749 section 5.1.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// tokenErrorResponse is the error response of a token endpoint, see RFC 6749 section 5.2.
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (s *clientCredentials) Token(ctx context.Context) (string, error) {
+	return s.cached.get(func() (string, time.Time, error) {
+		return s.fetch(ctx)
+	})
+}
+
+func (s *clientCredentials) Invalidate(token string) {
+	s.cached.invalidate(token)
+}
+
+// fetch requests a new token from the token endpoint.
+func (s *clientCredentials) fetch(ctx context.Context) (string, time.Time, error) {
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Requesting an OAuth2 token", map[string]interface{}{"token_url": s.tokenUrl, "client_id": s.clientId})
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.scopes) > 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.tokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid OAuth2 token URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.clientId), url.QueryEscape(s.clientSecret))
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to request an OAuth2 token from %s: %w", s.tokenUrl, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read the OAuth2 token response of %s: %w", s.tokenUrl, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errorResponse tokenErrorResponse
+		if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error != "" {
+			return "", time.Time{}, fmt.Errorf("the OAuth2 token request to %s failed with HTTP Status Code %d: %s %s", s.tokenUrl, resp.StatusCode, errorResponse.Error, errorResponse.ErrorDescription)
+		}
+		return "", time.Time{}, fmt.Errorf("the OAuth2 token request to %s failed with HTTP Status Code %d", s.tokenUrl, resp.StatusCode)
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil || token.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("the OAuth2 token response of %s does not contain an access token", s.tokenUrl)
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return "", time.Time{}, fmt.Errorf("the OAuth2 token response of %s contains an unsupported token type %s", s.tokenUrl, token.TokenType)
+	}
+	var expiry time.Time
+	if token.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received an OAuth2 token", map[string]interface{}{"token_url": s.tokenUrl, "expires_in": token.ExpiresIn})
+	return token.AccessToken, expiry, nil
+}
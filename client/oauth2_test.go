@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// oauth2Server is a token endpoint issuing the tokens token-1, token-2, ... and a Hyperfabric service accepting the
+// tokens issued from the token of acceptedFrom.
+type oauth2Server struct {
+	*httptest.Server
+	lock         sync.Mutex
+	expiresIn    int
+	issued       int
+	acceptedFrom int
+	tokenForms   []string
+	bearers      []string
+}
+
+func newOAuth2Server(t *testing.T, expiresIn int) *oauth2Server {
+	s := &oauth2Server{expiresIn: expiresIn, acceptedFrom: 1}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if r.URL.Path == "/oauth2/token" {
+			clientId, clientSecret, _ := r.BasicAuth()
+			r.ParseForm()
+			s.tokenForms = append(s.tokenForms, fmt.Sprintf("%s:%s %s %s", clientId, clientSecret, r.PostForm.Get("grant_type"), r.PostForm.Get("scope")))
+			if clientSecret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": "invalid_client", "error_description": "unknown client"}`))
+				return
+			}
+			s.issued++
+			fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": %d}`, s.issued, s.expiresIn)
+			return
+		}
+		bearer := r.Header.Get("Authorization")
+		s.bearers = append(s.bearers, bearer)
+		var issued int
+		fmt.Sscanf(bearer, "Bearer token-%d", &issued)
+		if issued < s.acceptedFrom {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errCode": "ERR_CODE_UNAUTHENTICATED", "status": 401}`))
+			return
+		}
+		w.Write([]byte(`{"fabricId": "f1"}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *oauth2Server) newClient(clientSecret string) *Client {
+	return NewClient(s.URL, "", MaxRetries(0), OAuth2ClientCredentials("terraform", clientSecret, s.URL+"/oauth2/token", []string{"read", "write"}))
+}
+
+func TestOAuth2TokenIsCached(t *testing.T) {
+	server := newOAuth2Server(t, 3600)
+	c := server.newClient("secret")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(server.tokenForms) != 1 || server.tokenForms[0] != "terraform:secret client_credentials read write" {
+		t.Errorf("expected a single client credentials token request, got %q", server.tokenForms)
+	}
+	for _, bearer := range server.bearers {
+		if bearer != "Bearer token-1" {
+			t.Errorf("expected the requests with the cached token, got %q", server.bearers)
+			break
+		}
+	}
+}
+
+func TestOAuth2ExpiringTokenIsRefreshed(t *testing.T) {
+	server := newOAuth2Server(t, 10)
+	c := server.newClient("secret")
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(server.bearers) != 2 || server.bearers[1] != "Bearer token-2" {
+		t.Errorf("expected the token expiring within %s to be refreshed, got %q", tokenExpiryDelta, server.bearers)
+	}
+}
+
+func TestOAuth2RejectedTokenIsRefreshed(t *testing.T) {
+	server := newOAuth2Server(t, 3600)
+	c := server.newClient("secret")
+	if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// token-1 is revoked
+	server.acceptedFrom = 2
+	if _, err := c.Fabrics.Update(context.Background(), "f1", &Fabric{Name: String("fabric1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(server.bearers) != 3 || server.bearers[1] != "Bearer token-1" || server.bearers[2] != "Bearer token-2" {
+		t.Errorf("expected a retry with a new token, got %q", server.bearers)
+	}
+
+	// every token is rejected
+	server.acceptedFrom = 100
+	if _, err := c.Fabrics.Get(context.Background(), "f2"); !strings.Contains(fmt.Sprint(err), "401") {
+		t.Errorf("expected the rejection of the new token to be returned, got %v", err)
+	}
+	if len(server.bearers) != 5 {
+		t.Errorf("expected a single retry with a new token, got %q", server.bearers)
+	}
+}
+
+func TestOAuth2TokenRequestFailure(t *testing.T) {
+	server := newOAuth2Server(t, 3600)
+	c := server.newClient("wrong")
+
+	_, err := c.Fabrics.Get(context.Background(), "f1")
+	if err == nil || !strings.Contains(err.Error(), "invalid_client unknown client") {
+		t.Errorf("expected the error of the token endpoint, got %v", err)
+	}
+	if len(server.bearers) != 0 {
+		t.Errorf("expected no request without a token, got %q", server.bearers)
+	}
+}
@@ -0,0 +1,161 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListOptions are the options of the list endpoints of the Hyperfabric API.
+type ListOptions struct {
+	// PageSize is the number of objects requested per page, the Hyperfabric service default is used when zero.
+	PageSize int
+}
+
+// pageInfo holds the pagination fields of the response of a list endpoint. A response continues either with
+// a cursor in nextPageToken, which is sent back as pageToken, or with the number of the next page, counted from 1 by
+// the iterator, while fewer than totalPages pages have been received. A response without these fields holds all the
+// objects of the collection.
+type pageInfo struct {
+	NextPageToken string
+	TotalPages    int
+}
+
+// Iterator iterates over the objects of a list endpoint, requesting the next page when the current page is exhausted.
+//
+//	it := client.Devices.Iter(&ListOptions{PageSize: 100})
+//	for it.Next(ctx) {
+//		device := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	client   *Client
+	path     string
+	envelope string
+	opts     ListOptions
+
+	page       []T
+	index      int
+	value      T
+	pageToken  string
+	pageNumber int
+	pages      int
+	done       bool
+	err        error
+}
+
+func newIterator[T any](c *Client, path, envelope string, opts *ListOptions) *Iterator[T] {
+	it := &Iterator[T]{
+		client:   c,
+		path:     path,
+		envelope: envelope,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next advances the iterator to the next object and reports whether there is one.
+// It returns false at the end of the collection or when a request failed, see Err.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch(ctx)
+	}
+	it.value = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current object of the iterator.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All returns the remaining objects of the iterator.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var objects []T
+	for it.Next(ctx) {
+		objects = append(objects, it.Value())
+	}
+	return objects, it.Err()
+}
+
+func (it *Iterator[T]) fetch(ctx context.Context) {
+	query := url.Values{}
+	if it.opts.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(it.opts.PageSize))
+	}
+	if it.pageToken != "" {
+		query.Set("pageToken", it.pageToken)
+	} else if it.pageNumber > 0 {
+		query.Set("page", strconv.Itoa(it.pageNumber))
+	}
+	path := it.path
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path = path + separator + query.Encode()
+	}
+
+	var response map[string]json.RawMessage
+	found, err := it.client.doJSON(ctx, "GET", path, nil, &response)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.done = true
+	if !found {
+		it.page, it.index = nil, 0
+		return
+	}
+	it.page, it.err = decodeEnvelope[T](response, it.envelope, path)
+	it.index = 0
+	if it.err != nil || len(it.page) == 0 {
+		return
+	}
+
+	it.pages++
+
+	var info pageInfo
+	fields := map[string]interface{}{"nextPageToken": &info.NextPageToken, "totalPages": &info.TotalPages}
+	for key, field := range fields {
+		if raw, ok := response[key]; ok {
+			if err := json.Unmarshal(raw, field); err != nil {
+				it.err = fmt.Errorf("decoding of the %s of the JSON response of %s failed: %w", key, path, err)
+				return
+			}
+		}
+	}
+	// A repeated cursor would request the same page forever.
+	if info.NextPageToken != "" && info.NextPageToken != it.pageToken {
+		it.pageToken = info.NextPageToken
+		it.done = false
+	} else if info.NextPageToken == "" && it.pages < info.TotalPages {
+		// The page number of the response is not relied upon, since a response without it would request the same
+		// page again.
+		it.pageNumber = it.pages + 1
+		it.done = false
+	}
+}
@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestIteratorFollowsPageTokens(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"devices": [{"deviceId": "d1"}, {"deviceId": "d2"}], "nextPageToken": "p2"}`,
+		"p2": `{"devices": [{"deviceId": "d3"}], "nextPageToken": "p3"}`,
+		"p3": `{"devices": [{"deviceId": "d4"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageSize") != "2" {
+			t.Errorf("expected a pageSize of 2, got %q", r.URL.RawQuery)
+		}
+		w.Write([]byte(pages[r.URL.Query().Get("pageToken")]))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	devices, err := c.Devices.Iter(&ListOptions{PageSize: 2}).All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(devices) != 4 || devices[3].DeviceId != "d4" {
+		t.Errorf("expected the devices of every page, got %+v", devices)
+	}
+}
+
+func TestIteratorFollowsPageNumbers(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		fmt.Fprintf(w, `{"ports": [{"id": "m%s"}], "page": %s, "totalPages": 3}`, page, page)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	managementPorts, err := c.ManagementPorts.List(context.Background(), "f1", "n1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managementPorts) != 3 || managementPorts[2].Id != "m3" || requests != 3 {
+		t.Errorf("expected 3 management ports in 3 requests, got %+v in %d requests", managementPorts, requests)
+	}
+}
+
+func TestIteratorCountsPagesWithoutPageNumbers(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+		if len(requested) > 3 {
+			t.Errorf("expected the iteration to stop after totalPages, got requests of pages %q", requested)
+			return
+		}
+		// The responses hold totalPages but not the number of their page.
+		fmt.Fprintf(w, `{"ports": [{"id": "m%d"}], "totalPages": 3}`, len(requested))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	managementPorts, err := c.ManagementPorts.List(context.Background(), "f1", "n1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managementPorts) != 3 || !reflect.DeepEqual(requested, []string{"", "2", "3"}) {
+		t.Errorf("expected 3 management ports from the pages counted by the iterator, got %+v from pages %q", managementPorts, requested)
+	}
+}
+
+func TestIteratorStopsEarly(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"devices": [{"deviceId": "d1"}], "nextPageToken": "next"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	it := c.Devices.Iter(nil)
+	if !it.Next(context.Background()) || it.Value().DeviceId != "d1" {
+		t.Fatalf("expected a first device, err: %v", it.Err())
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request before the end of the first page, got %d", requests)
+	}
+	// The second page repeats the cursor of the first page, which ends the iteration.
+	devices, err := it.All(context.Background())
+	if err != nil || len(devices) != 1 || requests != 2 {
+		t.Errorf("expected one more device in one more request, got %+v in %d requests, err: %v", devices, requests, err)
+	}
+}
+
+func TestIteratorReturnsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errCode": "ERR_CODE_PERMISSION_DENIED", "status": 403}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	it := c.Devices.Iter(nil)
+	if it.Next(context.Background()) {
+		t.Fatal("expected no device")
+	}
+	if it.Err() == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Port is a physical port of a node.
+type Port struct {
+	Id            string       `json:"id,omitempty"`
+	FabricId      string       `json:"fabricId,omitempty"`
+	NodeId        string       `json:"nodeId,omitempty"`
+	Name          *string      `json:"name,omitempty"`
+	Description   *string      `json:"description,omitempty"`
+	Enabled       *bool        `json:"enabled,omitempty"`
+	Index         *float64     `json:"index,omitempty"`
+	Ipv4Addresses []string     `json:"ipv4Addresses"`
+	Ipv6Addresses []string     `json:"ipv6Addresses"`
+	Linecard      *float64     `json:"linecard,omitempty"`
+	LinkDown      *bool        `json:"linkDown,omitempty"`
+	LldpHost      *string      `json:"lldpHost,omitempty"`
+	LldpInfo      *string      `json:"lldpInfo,omitempty"`
+	LldpPort      *string      `json:"lldpPort,omitempty"`
+	MaxSpeed      *string      `json:"maxSpeed,omitempty"`
+	Mtu           *float64     `json:"mtu,omitempty"`
+	Roles         []string     `json:"roles"`
+	Speed         *string      `json:"speed,omitempty"`
+	SubInfCount   *float64     `json:"subInfCount,omitempty"`
+	VlanIds       []string     `json:"vlanIds"`
+	Vnis          []string     `json:"vnis"`
+	VrfId         *string      `json:"vrfId,omitempty"`
+	Metadata      *Metadata    `json:"metadata,omitempty"`
+	Labels        []string     `json:"labels"`
+	Annotations   []Annotation `json:"annotations"`
+}
+
+// MarshalJSON omits the nil lists of the port from its JSON payload, its empty lists are sent to clear them.
+func (p Port) MarshalJSON() ([]byte, error) {
+	type port Port
+	return marshalWithoutNilLists(port(p))
+}
+
+// PortsService handles the /api/v1/fabrics/{fabricId}/nodes/{nodeId}/ports endpoints.
+// Ports always exist on a node, they are configured rather than created.
+type PortsService service
+
+// Get returns the port identified by its id or name, or nil when it does not exist.
+func (s *PortsService) Get(ctx context.Context, fabricId, nodeId, portId string) (*Port, error) {
+	return getObject[Port](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/ports/%s", fabricId, nodeId, portId))
+}
+
+// Update replaces the configuration of the port identified by its id or name.
+func (s *PortsService) Update(ctx context.Context, fabricId, nodeId, portId string, port *Port) (*Port, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/ports/%s", fabricId, nodeId, portId), port)
+}
+
+// Delete resets the configuration of the port identified by its id or name.
+func (s *PortsService) Delete(ctx context.Context, fabricId, nodeId, portId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/ports/%s", fabricId, nodeId, portId))
+}
@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// NoProxy option: comma-separated hosts, domains, IP addresses and CIDR ranges reached without proxy, in the format of
+// the NO_PROXY environment variable, which it replaces.
+func NoProxy(noProxy string) Option {
+	return func(client *Client) {
+		client.noProxy = noProxy
+	}
+}
+
+// ValidateProxyUrl returns an error when proxyUrl is not the URL of an HTTP, HTTPS or SOCKS5 proxy.
+func ValidateProxyUrl(proxyUrl string) error {
+	_, err := parseProxyUrl(proxyUrl)
+	return err
+}
+
+func parseProxyUrl(proxyUrl string) (*url.URL, error) {
+	if !strings.Contains(proxyUrl, "://") {
+		// a proxy without scheme is an HTTP proxy, as in the proxy environment variables
+		proxyUrl = "http://" + proxyUrl
+	}
+	pUrl, err := url.Parse(proxyUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch pUrl.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %s: the scheme must be http, https, socks5 or socks5h", pUrl.Redacted())
+	}
+	if pUrl.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %s: the host is missing", pUrl.Redacted())
+	}
+	return pUrl, nil
+}
+
+// proxyFunc returns the function selecting the proxy of the requests of the client, with the semantics of
+// http.ProxyFromEnvironment: the HTTPS_PROXY and HTTP_PROXY environment variables, replaced by the ProxyUrl option
+// when it is set, except for the hosts of the NO_PROXY environment variable, replaced by the NoProxy option when it is
+// set. The credentials of the ProxyCreds option are set on the proxies without credentials, and are sent with the Basic
+// scheme to HTTP proxies and with the username/password method to SOCKS5 proxies.
+func (c *Client) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	config := httpproxy.FromEnvironment()
+	if c.proxyUrl != "" {
+		pUrl, err := parseProxyUrl(c.proxyUrl)
+		if err != nil {
+			return nil, err
+		}
+		config.HTTPProxy = pUrl.String()
+		config.HTTPSProxy = pUrl.String()
+	}
+	if c.noProxy != "" {
+		config.NoProxy = c.noProxy
+	}
+	var user *url.Userinfo
+	if c.proxyCreds != "" {
+		username, password, _ := strings.Cut(c.proxyCreds, ":")
+		user = url.UserPassword(username, password)
+	}
+
+	proxyForUrl := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		pUrl, err := proxyForUrl(req.URL)
+		if pUrl != nil && pUrl.User == nil && user != nil {
+			withUser := *pUrl
+			withUser.User = user
+			pUrl = &withUser
+		}
+		return pUrl, err
+	}, nil
+}
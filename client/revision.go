@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type revisionKey struct{}
+
+// WithRevision returns a context making the PUT and DELETE requests sent with it conditional on the object
+// still being at revisionId, through the If-Match header. The Hyperfabric service rejects the request with
+// 412 Precondition Failed when the object was modified since, see IsRevisionMismatch and RetryOnConflict.
+// An empty revisionId leaves the requests unconditional.
+func WithRevision(ctx context.Context, revisionId string) context.Context {
+	if revisionId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, revisionKey{}, revisionId)
+}
+
+func revisionFromContext(ctx context.Context) (string, bool) {
+	revisionId, ok := ctx.Value(revisionKey{}).(string)
+	return revisionId, ok
+}
+
+// RetryOnConflict option: re-reads the object and retries once with its current revision when a PUT or DELETE
+// request is rejected because the object was modified since the revision of WithRevision, instead of returning
+// the error. The changes made in the meantime are overwritten.
+func RetryOnConflict(retryOnConflict bool) Option {
+	return func(client *Client) {
+		client.retryOnConflict = retryOnConflict
+	}
+}
+
+// IsRevisionMismatch reports whether err was returned for a request rejected because the object was modified
+// since the revision given to WithRevision.
+func IsRevisionMismatch(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusPreconditionFailed
+}
+
+// currentRevision returns the revision of the object at path as returned by the Hyperfabric service.
+func (c *Client) currentRevision(ctx context.Context, path string) (string, error) {
+	container, diagError := c.DoRestRequestWithContext(ctx, path, "GET", nil)
+	if diagError != nil {
+		return "", diagError
+	}
+	if container == nil || !container.Exists("metadata", "revisionId") {
+		return "", fmt.Errorf("the revision of %s is unknown", path)
+	}
+	return fmt.Sprint(container.Search("metadata", "revisionId").Data()), nil
+}
+
+// retryWithCurrentRevision returns the context of the retry of a request to path rejected with err,
+// when err is a revision mismatch and the client retries on conflict.
+func (c *Client) retryWithCurrentRevision(ctx context.Context, method, path string, err error) (context.Context, bool) {
+	if !c.retryOnConflict || !IsRevisionMismatch(err) {
+		return ctx, false
+	}
+	revisionId, revisionErr := c.currentRevision(ctx, path)
+	if revisionErr != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Reading of the current revision failed, the request is not retried", map[string]interface{}{"method": method, "path": path, "error": revisionErr})
+		return ctx, false
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Retrying with the current revision of the object", map[string]interface{}{"method": method, "path": path, "revision_id": revisionId})
+	return WithRevision(ctx, revisionId), true
+}
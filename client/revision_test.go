@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// newRevisionServer returns a server holding a single fabric at the revision returned by revision, which rejects
+// the PUT and DELETE requests with another revision in their If-Match header.
+func newRevisionServer(t *testing.T, revision func() string) (*httptest.Server, *[]string) {
+	var lock sync.Mutex
+	var ifMatches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprintf(w, `{"fabricId": "f1", "metadata": {"revisionId": "%s"}}`, revision())
+			return
+		}
+		lock.Lock()
+		ifMatches = append(ifMatches, r.Header.Get("If-Match"))
+		lock.Unlock()
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != revision() {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"errCode": "ERR_CODE_PRECONDITION_FAILED", "status": 412}`))
+			return
+		}
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprintf(w, `{"fabricId": "f1", "metadata": {"revisionId": "%s"}}`, revision())
+	}))
+	t.Cleanup(server.Close)
+	return server, &ifMatches
+}
+
+func TestWithRevisionSendsIfMatch(t *testing.T) {
+	server, ifMatches := newRevisionServer(t, func() string { return "3" })
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	ctx := WithRevision(context.Background(), "3")
+	if _, err := c.Fabrics.Update(ctx, "f1", &Fabric{Name: String("fabric1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Fabrics.Delete(ctx, "f1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Fabrics.Update(context.Background(), "f1", &Fabric{Name: String("fabric1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*ifMatches) != 3 || (*ifMatches)[0] != "3" || (*ifMatches)[1] != "3" || (*ifMatches)[2] != "" {
+		t.Errorf("expected the revision on the requests with a revision only, got %q", *ifMatches)
+	}
+}
+
+func TestRevisionMismatch(t *testing.T) {
+	server, ifMatches := newRevisionServer(t, func() string { return "4" })
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	_, err := c.Fabrics.Update(WithRevision(context.Background(), "3"), "f1", &Fabric{Name: String("fabric1")})
+	if !IsRevisionMismatch(err) || !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a revision mismatch, got %v", err)
+	}
+	if len(*ifMatches) != 1 {
+		t.Errorf("expected no retry, got %d requests", len(*ifMatches))
+	}
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	server, ifMatches := newRevisionServer(t, func() string { return "4" })
+
+	c := NewClient(server.URL, "token", MaxRetries(0), RetryOnConflict(true))
+	fabric, err := c.Fabrics.Update(WithRevision(context.Background(), "3"), "f1", &Fabric{Name: String("fabric1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fabric.Metadata.RevisionId != "4" {
+		t.Errorf("expected the fabric at the current revision, got %+v", fabric.Metadata)
+	}
+	if len(*ifMatches) != 2 || (*ifMatches)[1] != "4" {
+		t.Errorf("expected a retry with the current revision, got %q", *ifMatches)
+	}
+}
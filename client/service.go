@@ -0,0 +1,175 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Jeffail/gabs/v2"
+)
+
+// service is the common base of the typed services of the Hyperfabric API, see initServices.
+type service struct {
+	client *Client
+}
+
+func (c *Client) initServices() {
+	c.common.client = c
+	c.BearerTokens = (*BearerTokensService)(&c.common)
+	c.Candidates = (*CandidatesService)(&c.common)
+	c.Connections = (*ConnectionsService)(&c.common)
+	c.Devices = (*DevicesService)(&c.common)
+	c.Fabrics = (*FabricsService)(&c.common)
+	c.Loopbacks = (*LoopbacksService)(&c.common)
+	c.ManagementPorts = (*ManagementPortsService)(&c.common)
+	c.Nodes = (*NodesService)(&c.common)
+	c.Ports = (*PortsService)(&c.common)
+	c.SubInterfaces = (*SubInterfacesService)(&c.common)
+	c.Users = (*UsersService)(&c.common)
+	c.Vnis = (*VnisService)(&c.common)
+	c.Vrfs = (*VrfsService)(&c.common)
+}
+
+// marshalWithoutNilLists marshals v, an object of the Hyperfabric API without MarshalJSON method, without its null
+// fields. The lists of the objects are marshalled even when empty, so that an update can clear them, and their nil
+// lists, which are left unchanged, are dropped here since only the lists can be null.
+func marshalWithoutNilLists(v interface{}) ([]byte, error) {
+	marshalled, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(marshalled, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range fields {
+		if string(value) == "null" {
+			delete(fields, name)
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// doJSON sends in, when not nil, as the JSON payload of the request and decodes the response into out, when not nil.
+// It returns false when the Hyperfabric service returned no object, such as for a 404 Not Found on a GET or DELETE request.
+// Errors returned by the Hyperfabric service are returned as *DiagError.
+func (c *Client) doJSON(ctx context.Context, method, path string, in, out interface{}) (bool, error) {
+	ctx = c.logContext(ctx)
+	var payload *gabs.Container
+	if in != nil {
+		marshalPayload, err := json.Marshal(in)
+		if err != nil {
+			return false, fmt.Errorf("marshalling of the JSON payload for %s %s failed: %w", method, path, err)
+		}
+		payload, err = gabs.ParseJSON(marshalPayload)
+		if err != nil {
+			return false, fmt.Errorf("construction of the JSON payload for %s %s failed: %w", method, path, err)
+		}
+	}
+
+	container, diagError := c.DoRestRequestWithContext(ctx, path, method, payload)
+	if diagError != nil {
+		retryCtx, retry := c.retryWithCurrentRevision(ctx, method, path, diagError)
+		if !retry {
+			return false, diagError
+		}
+		container, diagError = c.DoRestRequestWithContext(retryCtx, path, method, payload)
+		if diagError != nil {
+			return false, diagError
+		}
+	}
+	if container == nil || container.Data() == nil {
+		return false, nil
+	}
+	if out != nil {
+		if err := json.Unmarshal(container.Bytes(), out); err != nil {
+			return false, fmt.Errorf("decoding of the JSON response of %s %s failed: %w", method, path, err)
+		}
+	}
+	return true, nil
+}
+
+// String returns a pointer to v, for use in the optional fields of the API types.
+func String(v string) *string { return &v }
+
+// Bool returns a pointer to v, for use in the optional fields of the API types.
+func Bool(v bool) *bool { return &v }
+
+// Float64 returns a pointer to v, for use in the optional fields of the API types.
+func Float64(v float64) *float64 { return &v }
+
+// getObject returns the object at path, or nil when it does not exist.
+func getObject[T any](ctx context.Context, c *Client, path string) (*T, error) {
+	var object T
+	found, err := c.doJSON(ctx, "GET", path, nil, &object)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &object, nil
+}
+
+// listObjects returns the objects listed under the envelope key of every page of the response of path.
+func listObjects[T any](ctx context.Context, c *Client, path, envelope string) ([]T, error) {
+	return newIterator[T](c, path, envelope, nil).All(ctx)
+}
+
+// createObject wraps object in the envelope expected by the collection at path and returns the first object of the response.
+// Nil is returned when the Hyperfabric service did not return any object. The creation is sent in a batch with the
+// concurrent creations in the same collection when the client batches creations, see BatchCreates.
+func createObject[T any](ctx context.Context, c *Client, method, path, envelope string, object *T) (*T, error) {
+	var created json.RawMessage
+	if c.batchCreates {
+		var err error
+		if created, err = c.createBatcher.create(ctx, c, method, path, envelope, object); err != nil {
+			return nil, err
+		}
+	} else {
+		objects, err := c.createObjects(ctx, method, path, envelope, []interface{}{object})
+		if err != nil {
+			return nil, err
+		}
+		if len(objects) > 0 {
+			created = objects[0]
+		}
+	}
+	if created == nil {
+		return nil, nil
+	}
+	var createdObject T
+	if err := json.Unmarshal(created, &createdObject); err != nil {
+		return nil, fmt.Errorf("decoding of the %s of the JSON response of %s failed: %w", envelope, path, err)
+	}
+	return &createdObject, nil
+}
+
+// updateObject replaces the object at path and returns the object of the response, if any.
+func updateObject[T any](ctx context.Context, c *Client, path string, object *T) (*T, error) {
+	var updated T
+	found, err := c.doJSON(ctx, "PUT", path, object, &updated)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// deleteObject deletes the object at path, deleting an object that does not exist is not an error.
+func deleteObject(ctx context.Context, c *Client, path string) error {
+	_, err := c.doJSON(ctx, "DELETE", path, nil, nil)
+	return err
+}
+
+func decodeEnvelope[T any](response map[string]json.RawMessage, envelope, path string) ([]T, error) {
+	var objects []T
+	if raw, ok := response[envelope]; ok {
+		if err := json.Unmarshal(raw, &objects); err != nil {
+			return nil, fmt.Errorf("decoding of the %s of the JSON response of %s failed: %w", envelope, path, err)
+		}
+	}
+	return objects, nil
+}
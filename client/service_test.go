@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFabricsCreateWrapsPayloadInEnvelope(t *testing.T) {
+	var received map[string][]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/fabrics" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"fabrics": [{"fabricId": "f1", "name": "fabric1", "labels": ["a"]}]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	fabric, err := c.Fabrics.Create(context.Background(), &Fabric{Name: String("fabric1"), Topology: String("MESH")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fabric == nil || fabric.FabricId != "f1" || fabric.Name == nil || *fabric.Name != "fabric1" || len(fabric.Labels) != 1 {
+		t.Fatalf("unexpected fabric %+v", fabric)
+	}
+	if len(received["fabrics"]) != 1 {
+		t.Fatalf("expected one fabric in the fabrics envelope, got %v", received)
+	}
+	if _, ok := received["fabrics"][0]["description"]; ok {
+		t.Errorf("expected unset fields to be omitted from the payload, got %v", received["fabrics"][0])
+	}
+	if received["fabrics"][0]["topology"] != "MESH" {
+		t.Errorf("expected topology MESH in the payload, got %v", received["fabrics"][0])
+	}
+}
+
+func TestNodesGetNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/fabrics/f1/nodes/n1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errCode": "ERR_CODE_NOT_FOUND", "status": 404}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	node, err := c.Nodes.Get(context.Background(), "f1", "n1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if node != nil {
+		t.Errorf("expected no node, got %+v", node)
+	}
+}
+
+func TestVrfsUpdateReturnsDiagError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errCode": "ERR_CODE_BAD_REQUEST", "status": 400, "errMessage": "invalid asn", "field": "asn"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	_, err := c.Vrfs.Update(context.Background(), "f1", "v1", &Vrf{Asn: Float64(-1)})
+	var diagError *DiagError
+	if !errors.As(err, &diagError) {
+		t.Fatalf("expected a *DiagError, got %v", err)
+	}
+}
+
+func TestBearerTokensCreateReturnsRootToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tokens": [{"tokenId": "t1", "name": "token1"}], "token": "secret"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	bearerToken, err := c.BearerTokens.Create(context.Background(), &BearerToken{Name: String("token1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bearerToken == nil || bearerToken.TokenId != "t1" || bearerToken.Token == nil || *bearerToken.Token != "secret" {
+		t.Fatalf("unexpected bearer token %+v", bearerToken)
+	}
+}
+
+func TestFabricsUpdateSendsEmptyLists(t *testing.T) {
+	var received map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/api/v1/fabrics/f1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"fabricId": "f1", "name": "fabric1"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	_, err := c.Fabrics.Update(context.Background(), "f1", &Fabric{Name: String("fabric1"), Labels: []string{}, Annotations: []Annotation{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(received["labels"]) != "[]" || string(received["annotations"]) != "[]" {
+		t.Errorf("expected the emptied labels and annotations to be sent as empty lists, got %v", received)
+	}
+}
+
+func TestVnisUpdateOmitsNilLists(t *testing.T) {
+	var received map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"id": "v1", "name": "vni1"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(0))
+	_, err := c.Vnis.Update(context.Background(), "f1", "v1", &Vni{Name: String("vni1"), Labels: []string{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, field := range []string{"members", "svis", "annotations"} {
+		if value, ok := received[field]; ok {
+			t.Errorf("expected the nil %s to be omitted, got %s", field, value)
+		}
+	}
+	if string(received["labels"]) != "[]" || string(received["name"]) != `"vni1"` {
+		t.Errorf("expected the name and the emptied labels, got %v", received)
+	}
+}
@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// latencyBucketBounds are the upper bounds, in seconds, of the buckets of the latency histograms of the API
+// statistics. The last bucket of a histogram counts the requests longer than the last bound.
+var latencyBucketBounds = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Stats is the usage of the Hyperfabric API by a client since its creation, see Client.Stats.
+type Stats struct {
+	URL string `json:"url"`
+	// Requests counts the HTTP requests sent, including the retries.
+	Requests         int     `json:"requests"`
+	Retries          int     `json:"retries"`
+	ConnectionErrors int     `json:"connection_errors"`
+	BackoffSeconds   float64 `json:"backoff_seconds"`
+	// StatusCodes counts the responses by HTTP status code.
+	StatusCodes map[string]int  `json:"status_codes"`
+	Endpoints   []EndpointStats `json:"endpoints"`
+}
+
+// EndpointStats is the usage of an endpoint of the Hyperfabric API, identified by its method and path template
+// such as /api/v1/fabrics/{fabricId}/nodes.
+type EndpointStats struct {
+	Method           string           `json:"method"`
+	Path             string           `json:"path"`
+	Requests         int              `json:"requests"`
+	Retries          int              `json:"retries"`
+	ConnectionErrors int              `json:"connection_errors"`
+	StatusCodes      map[string]int   `json:"status_codes"`
+	Latency          LatencyHistogram `json:"latency"`
+}
+
+// LatencyHistogram is the distribution of the durations of the HTTP requests to an endpoint. BucketCounts[i] counts
+// the requests lasting at most BucketBounds[i] seconds and longer than the previous bound, and the last bucket the
+// requests longer than the last bound.
+type LatencyHistogram struct {
+	Count        int       `json:"count"`
+	SumSeconds   float64   `json:"sum_seconds"`
+	MaxSeconds   float64   `json:"max_seconds"`
+	BucketBounds []float64 `json:"bucket_bounds"`
+	BucketCounts []int     `json:"bucket_counts"`
+}
+
+// apiStats collects the usage of the Hyperfabric API by a client, its zero value is ready to use.
+type apiStats struct {
+	lock      sync.Mutex
+	backoff   time.Duration
+	endpoints map[string]*EndpointStats
+}
+
+// recordAttempt records an attempt of a request to the endpoint of method and template, which received a response
+// with status, or failed with a connection error when status is zero. attempts is the number of previous attempts.
+func (s *apiStats) recordAttempt(method, template string, attempts, status int, duration time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := method + " " + template
+	endpoint, ok := s.endpoints[key]
+	if !ok {
+		if s.endpoints == nil {
+			s.endpoints = map[string]*EndpointStats{}
+		}
+		endpoint = &EndpointStats{
+			Method:      method,
+			Path:        template,
+			StatusCodes: map[string]int{},
+			Latency: LatencyHistogram{
+				BucketBounds: latencyBucketBounds,
+				BucketCounts: make([]int, len(latencyBucketBounds)+1),
+			},
+		}
+		s.endpoints[key] = endpoint
+	}
+	endpoint.Requests++
+	if attempts > 0 {
+		endpoint.Retries++
+	}
+	if status == 0 {
+		endpoint.ConnectionErrors++
+	} else {
+		endpoint.StatusCodes[strconv.Itoa(status)]++
+	}
+	seconds := duration.Seconds()
+	endpoint.Latency.Count++
+	endpoint.Latency.SumSeconds += seconds
+	if seconds > endpoint.Latency.MaxSeconds {
+		endpoint.Latency.MaxSeconds = seconds
+	}
+	endpoint.Latency.BucketCounts[sort.SearchFloat64s(latencyBucketBounds, seconds)]++
+}
+
+// recordBackoff records a wait between two attempts of a request.
+func (s *apiStats) recordBackoff(duration time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.backoff += duration
+}
+
+// Stats returns the usage of the Hyperfabric API by the client since its creation, with the endpoints sorted by path
+// and method. The responses served from the cache of CacheGetRequests are not counted.
+func (c *Client) Stats() Stats {
+	c.stats.lock.Lock()
+	defer c.stats.lock.Unlock()
+	stats := Stats{
+		URL:            c.baseURL.Redacted(),
+		BackoffSeconds: c.stats.backoff.Seconds(),
+		StatusCodes:    map[string]int{},
+		Endpoints:      make([]EndpointStats, 0, len(c.stats.endpoints)),
+	}
+	for _, endpoint := range c.stats.endpoints {
+		copied := *endpoint
+		copied.StatusCodes = make(map[string]int, len(endpoint.StatusCodes))
+		for status, count := range endpoint.StatusCodes {
+			copied.StatusCodes[status] = count
+			stats.StatusCodes[status] += count
+		}
+		copied.Latency.BucketCounts = append([]int(nil), endpoint.Latency.BucketCounts...)
+		stats.Requests += endpoint.Requests
+		stats.Retries += endpoint.Retries
+		stats.ConnectionErrors += endpoint.ConnectionErrors
+		stats.Endpoints = append(stats.Endpoints, copied)
+	}
+	sort.Slice(stats.Endpoints, func(i, j int) bool {
+		if stats.Endpoints[i].Path != stats.Endpoints[j].Path {
+			return stats.Endpoints[i].Path < stats.Endpoints[j].Path
+		}
+		return stats.Endpoints[i].Method < stats.Endpoints[j].Method
+	})
+	return stats
+}
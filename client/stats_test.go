@@ -0,0 +1,67 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/fabrics/f2" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errCode": "ERR_CODE_NOT_FOUND", "status": 404}`))
+			return
+		}
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"fabricId": "f1"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", MaxRetries(1))
+	if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Fabrics.Get(context.Background(), "f2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := c.Stats()
+	if stats.URL != server.URL || stats.Requests != 3 || stats.Retries != 1 || stats.ConnectionErrors != 0 {
+		t.Errorf("unexpected totals: %+v", stats)
+	}
+	if stats.BackoffSeconds < 1 {
+		t.Errorf("expected the Retry-After delay to be counted as backoff, got %f seconds", stats.BackoffSeconds)
+	}
+	if !reflect.DeepEqual(stats.StatusCodes, map[string]int{"200": 1, "404": 1, "503": 1}) {
+		t.Errorf("unexpected status codes: %v", stats.StatusCodes)
+	}
+	if len(stats.Endpoints) != 1 {
+		t.Fatalf("expected the requests to be grouped by path template, got %+v", stats.Endpoints)
+	}
+	endpoint := stats.Endpoints[0]
+	if endpoint.Method != "GET" || endpoint.Path != "/api/v1/fabrics/{fabricId}" || endpoint.Requests != 3 || endpoint.Retries != 1 {
+		t.Errorf("unexpected endpoint: %+v", endpoint)
+	}
+	bucketCounts := 0
+	for _, count := range endpoint.Latency.BucketCounts {
+		bucketCounts += count
+	}
+	if endpoint.Latency.Count != 3 || bucketCounts != 3 || len(endpoint.Latency.BucketCounts) != len(endpoint.Latency.BucketBounds)+1 {
+		t.Errorf("unexpected latency histogram: %+v", endpoint.Latency)
+	}
+}
@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// SubInterface is a VLAN sub-interface of a port of a node.
+type SubInterface struct {
+	Id            string       `json:"id,omitempty"`
+	FabricId      string       `json:"fabricId,omitempty"`
+	NodeId        string       `json:"nodeId,omitempty"`
+	Name          *string      `json:"name,omitempty"`
+	Description   *string      `json:"description,omitempty"`
+	Enabled       *bool        `json:"enabled,omitempty"`
+	Ipv4Addresses []string     `json:"ipv4Addresses"`
+	Ipv6Addresses []string     `json:"ipv6Addresses"`
+	VlanId        *float64     `json:"vlanId,omitempty"`
+	VrfId         *string      `json:"vrfId,omitempty"`
+	Parent        *string      `json:"parent,omitempty"`
+	Metadata      *Metadata    `json:"metadata,omitempty"`
+	Labels        []string     `json:"labels"`
+	Annotations   []Annotation `json:"annotations"`
+}
+
+// MarshalJSON omits the nil lists of the sub-interface from its JSON payload, its empty lists are sent to clear them.
+func (s SubInterface) MarshalJSON() ([]byte, error) {
+	type subInterface SubInterface
+	return marshalWithoutNilLists(subInterface(s))
+}
+
+// SubInterfacesService handles the /api/v1/fabrics/{fabricId}/nodes/{nodeId}/subInterfaces endpoints.
+type SubInterfacesService service
+
+// Get returns the sub-interface identified by its id or name, or nil when it does not exist.
+func (s *SubInterfacesService) Get(ctx context.Context, fabricId, nodeId, subInterfaceId string) (*SubInterface, error) {
+	return getObject[SubInterface](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/subInterfaces/%s", fabricId, nodeId, subInterfaceId))
+}
+
+// Create creates a sub-interface on a node and returns it as returned by the Hyperfabric service.
+func (s *SubInterfacesService) Create(ctx context.Context, fabricId, nodeId string, subInterface *SubInterface) (*SubInterface, error) {
+	return createObject(ctx, s.client, "POST", fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/subInterfaces", fabricId, nodeId), "subInterfaces", subInterface)
+}
+
+// Update replaces the sub-interface identified by subInterfaceId.
+func (s *SubInterfacesService) Update(ctx context.Context, fabricId, nodeId, subInterfaceId string, subInterface *SubInterface) (*SubInterface, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/subInterfaces/%s", fabricId, nodeId, subInterfaceId), subInterface)
+}
+
+// Delete deletes the sub-interface identified by subInterfaceId.
+func (s *SubInterfacesService) Delete(ctx context.Context, fabricId, nodeId, subInterfaceId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s/subInterfaces/%s", fabricId, nodeId, subInterfaceId))
+}
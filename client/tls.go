@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// CACert option: PEM encoded certificates of the certificate authorities trusted to verify the certificate of the
+// Hyperfabric service, in addition to the certificate authorities of the system. It allows the verification of the
+// certificates issued by a private certificate authority, such as the one of a TLS-inspecting proxy.
+func CACert(caCert string) Option {
+	return func(client *Client) {
+		client.caCert = caCert
+	}
+}
+
+// ValidateCertificates returns an error when the PEM encoded certificates of the CACert option or the client
+// certificate and private key of the AdminCert and PrivateKey options cannot be used.
+func ValidateCertificates(caCert, adminCert, privatekey string) error {
+	if _, err := certPool(caCert); err != nil {
+		return err
+	}
+	_, err := clientCertificates(adminCert, privatekey)
+	return err
+}
+
+// tlsConfig returns the TLS configuration of the connections to the Hyperfabric service.
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	rootCAs, err := certPool(c.caCert)
+	if err != nil {
+		return nil, err
+	}
+	certificates, err := clientCertificates(c.adminCert, c.privatekey)
+	if err != nil {
+		return nil, err
+	}
+	// TLS 1.2 or later, with the cipher suites selected by crypto/tls
+	return &tls.Config{
+		InsecureSkipVerify: c.insecure,
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            rootCAs,
+		Certificates:       certificates,
+	}, nil
+}
+
+// certPool returns the certificate pool of the system with the certificates of caCert, or nil to use the pool of the
+// system when caCert is empty.
+func certPool(caCert string) (*x509.CertPool, error) {
+	if caCert == "" {
+		return nil, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, errors.New("the CA certificate does not contain any PEM encoded certificate")
+	}
+	return pool, nil
+}
+
+// clientCertificates returns the client certificate presented to the Hyperfabric service, if any.
+func clientCertificates(adminCert, privatekey string) ([]tls.Certificate, error) {
+	if adminCert == "" && privatekey == "" {
+		return nil, nil
+	}
+	if adminCert == "" || privatekey == "" {
+		return nil, errors.New("both the client certificate and its private key are required")
+	}
+	certificate, err := tls.X509KeyPair([]byte(adminCert), []byte(privatekey))
+	if err != nil {
+		return nil, fmt.Errorf("the client certificate or its private key is invalid: %w", err)
+	}
+	return []tls.Certificate{certificate}, nil
+}
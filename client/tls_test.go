@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate is a certificate and its private key, PEM encoded.
+type testCertificate struct {
+	cert        *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPem     string
+	keyPem      string
+	certificate tls.Certificate
+}
+
+// newTestCertificate returns a certificate for 127.0.0.1 signed by parent, or self-signed CA certificate when parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	certificate := &testCertificate{
+		key:     key,
+		certPem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPem:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}
+	certificate.cert, _ = x509.ParseCertificate(der)
+	certificate.certificate, err = tls.X509KeyPair([]byte(certificate.certPem), []byte(certificate.keyPem))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return certificate
+}
+
+// newMutualTLSServer returns a server with a certificate signed by ca, which requires a client certificate signed by
+// ca and returns the common name of the client certificate and the Authorization header of the request.
+func newMutualTLSServer(t *testing.T, ca *testCertificate) *httptest.Server {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fabricId": "f1", "name": "` + r.TLS.PeerCertificates[0].Subject.CommonName + `", "description": "` + r.Header.Get("Authorization") + `"}`))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{newTestCertificate(t, "hyperfabric", ca).certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientCertificate(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	server := newMutualTLSServer(t, ca)
+	clientCert := newTestCertificate(t, "terraform", ca)
+
+	c := NewClient(server.URL, "", MaxRetries(0), CACert(ca.certPem), AdminCert(clientCert.certPem), PrivateKey(clientCert.keyPem))
+	fabric, err := c.Fabrics.Get(context.Background(), "f1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fabric == nil || *fabric.Name != "terraform" || *fabric.Description != "" {
+		t.Errorf("expected a request authenticated with the client certificate only, got %+v", fabric)
+	}
+}
+
+func TestCACertWithoutClientCertificate(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	server := newMutualTLSServer(t, ca)
+
+	if _, err := NewClient(server.URL, "token", MaxRetries(0)).Fabrics.Get(context.Background(), "f1"); err == nil {
+		t.Error("expected the certificate of the server signed by an unknown authority to be rejected")
+	}
+	if _, err := NewClient(server.URL, "token", MaxRetries(0), CACert(ca.certPem)).Fabrics.Get(context.Background(), "f1"); err == nil {
+		t.Error("expected the request without a client certificate to be rejected")
+	}
+}
+
+func TestValidateCertificates(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	clientCert := newTestCertificate(t, "terraform", ca)
+	otherCert := newTestCertificate(t, "other", ca)
+
+	tests := []struct {
+		name       string
+		caCert     string
+		adminCert  string
+		privatekey string
+		valid      bool
+	}{
+		{"none", "", "", "", true},
+		{"all", ca.certPem, clientCert.certPem, clientCert.keyPem, true},
+		{"invalid CA", "not a certificate", "", "", false},
+		{"certificate without key", "", clientCert.certPem, "", false},
+		{"key without certificate", "", "", clientCert.keyPem, false},
+		{"mismatching key", "", clientCert.certPem, otherCert.keyPem, false},
+	}
+	for _, test := range tests {
+		if err := ValidateCertificates(test.caCert, test.adminCert, test.privatekey); (err == nil) != test.valid {
+			t.Errorf("%s: expected valid %v, got %v", test.name, test.valid, err)
+		}
+	}
+
+	c := NewClient("https://127.0.0.1", "token", MaxRetries(0), CACert("not a certificate"))
+	if _, err := c.Fabrics.Get(context.Background(), "f1"); err == nil || !strings.Contains(err.Error(), "invalid TLS configuration") {
+		t.Errorf("expected the requests of a client with an invalid CA certificate to fail, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the OpenTelemetry tracer of the spans of the HTTP requests of the client.
+const TracerName = "github.com/cisco-open/terraform-provider-hyperfabric/client"
+
+// TracerProvider option: provider of the tracer of the spans of the HTTP requests of the client. The global tracer
+// provider of OpenTelemetry is used by default, which records nothing unless tracing is set up by the application.
+func TracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(client *Client) {
+		client.tracerProvider = tracerProvider
+	}
+}
+
+// startAttemptSpan starts the span of an attempt of req, a child of the span of ctx if any, and propagates its trace
+// context to the Hyperfabric service in the headers of req. attempts is the number of previous attempts of req.
+func (c *Client) startAttemptSpan(ctx context.Context, req *http.Request, attempts int) (context.Context, trace.Span) {
+	tracerProvider := c.tracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	template := pathTemplate(req.URL.Path)
+	ctx, span := tracerProvider.Tracer(TracerName).Start(ctx, fmt.Sprintf("%s %s", req.Method, template),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.template", template),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.Int("http.request.resend_count", attempts),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return ctx, span
+}
+
+// endAttemptSpan records the response, or the error, of an attempt on its span and ends it.
+func endAttemptSpan(span trace.Span, resp *http.Response, body []byte, err error) {
+	defer span.End()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode < 400 {
+		return
+	}
+	var restError struct {
+		ErrCode    string `json:"errCode"`
+		TrackingId string `json:"trackingId"`
+	}
+	if json.Unmarshal(body, &restError) == nil {
+		if restError.ErrCode != "" {
+			span.SetAttributes(attribute.String("hyperfabric.error_code", restError.ErrCode))
+		}
+		if restError.TrackingId != "" {
+			span.SetAttributes(attribute.String("hyperfabric.tracking_id", restError.TrackingId))
+		}
+	}
+	span.SetStatus(codes.Error, resp.Status)
+}
+
+// pathTemplate returns path with the object IDs replaced by placeholders named after their collections, such as
+// /api/v1/fabrics/{fabricId}/nodes/{nodeId}, so that the spans of the requests to the same endpoint can be grouped.
+// The paths of the Hyperfabric API alternate collections and object IDs after the API version.
+func pathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "api" || i+1 >= len(segments) {
+			continue
+		}
+		for j := i + 3; j < len(segments); j += 2 {
+			if segments[j] != "" {
+				segments[j] = fmt.Sprintf("{%sId}", strings.TrimSuffix(segments[j-1], "s"))
+			}
+		}
+		break
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPathTemplate(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/api/v1/fabrics":                              "/api/v1/fabrics",
+		"/api/v1/fabrics/f1":                           "/api/v1/fabrics/{fabricId}",
+		"/api/v1/fabrics/f1/nodes":                     "/api/v1/fabrics/{fabricId}/nodes",
+		"/api/v1/fabrics/f1/nodes/n1/subInterfaces/s1": "/api/v1/fabrics/{fabricId}/nodes/{nodeId}/subInterfaces/{subInterfaceId}",
+		"/api/v1/fabrics/f1/candidates/default":        "/api/v1/fabrics/{fabricId}/candidates/{candidateId}",
+		"/prefix/api/v1/bearerTokens/t1":               "/prefix/api/v1/bearerTokens/{bearerTokenId}",
+		"/oauth2/token":                                "/oauth2/token",
+	} {
+		if template := pathTemplate(path); template != expected {
+			t.Errorf("expected the template of %s to be %s, got %s", path, expected, template)
+		}
+	}
+}
+
+func TestAttemptSpans(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errCode": "ERR_CODE_SERVICE_UNAVAILABLE", "status": 503, "trackingId": "tracking-1"}`))
+			return
+		}
+		w.Write([]byte(`{"fabricId": "f1"}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	parentCtx, parent := tracerProvider.Tracer("test").Start(context.Background(), "hyperfabric_fabric.Read")
+	c := NewClient(server.URL, "token", MaxRetries(1), TracerProvider(tracerProvider))
+	if _, err := c.Fabrics.Get(parentCtx, "f1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected a span per attempt and the parent span, got %d spans", len(spans))
+	}
+	for i, expected := range []struct {
+		status     int64
+		trackingId string
+		code       codes.Code
+	}{
+		{503, "tracking-1", codes.Error},
+		{200, "", codes.Unset},
+	} {
+		span := spans[i]
+		if span.Name() != "GET /api/v1/fabrics/{fabricId}" {
+			t.Errorf("unexpected name of the span of attempt %d: %s", i, span.Name())
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("expected the span of attempt %d to be a child of the span of the operation", i)
+		}
+		attributes := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			attributes[kv.Key] = kv.Value
+		}
+		if attributes["http.request.method"].AsString() != "GET" || attributes["url.template"].AsString() != "/api/v1/fabrics/{fabricId}" {
+			t.Errorf("unexpected request attributes of the span of attempt %d: %v", i, span.Attributes())
+		}
+		if attributes["http.request.resend_count"].AsInt64() != int64(i) || attributes["http.response.status_code"].AsInt64() != expected.status {
+			t.Errorf("unexpected retry count or status of the span of attempt %d: %v", i, span.Attributes())
+		}
+		if attributes["hyperfabric.tracking_id"].AsString() != expected.trackingId {
+			t.Errorf("unexpected tracking ID of the span of attempt %d: %v", i, span.Attributes())
+		}
+		if span.Status().Code != expected.code {
+			t.Errorf("unexpected status of the span of attempt %d: %v", i, span.Status())
+		}
+	}
+}
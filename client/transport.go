@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Sizing of the pool of connections to the Hyperfabric service kept alive between requests.
+const (
+	defaultMaxIdleConnsPerHost = 16
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultDialTimeout         = 30 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// newTransport returns the transport of the client, dedicated to it so that its settings, such as insecure, the
+// certificates and the proxy, see proxyFunc, do not leak to http.DefaultTransport and the other clients.
+//
+// The connections are kept alive and reused by the consecutive and concurrent requests of the client, negotiating
+// HTTP/2 when the Hyperfabric service supports it, and the responses are requested gzip compressed.
+func (c *Client) newTransport() *http.Transport {
+	maxIdleConnsPerHost := defaultMaxIdleConnsPerHost
+	if c.maxConcurrentRequests > maxIdleConnsPerHost {
+		maxIdleConnsPerHost = c.maxConcurrentRequests
+	}
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   defaultDialTimeout,
+			KeepAlive: defaultKeepAlive,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConnsPerHost,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       defaultIdleConnTimeout,
+		TLSHandshakeTimeout:   defaultTLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	// the requests of the client fail with the errors of the configuration, see Do
+	proxy, err := c.proxyFunc()
+	if err != nil {
+		c.transportErr = fmt.Errorf("invalid proxy configuration: %w", err)
+		return transport
+	}
+	transport.Proxy = proxy
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		c.transportErr = fmt.Errorf("invalid TLS configuration: %w", err)
+		return transport
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport
+}
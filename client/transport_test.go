@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTransportIsDedicated(t *testing.T) {
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	defaultTLSConfig := defaultTransport.TLSClientConfig
+
+	c := NewClient("https://127.0.0.1", "token", Insecure(true), ProxyUrl("http://proxy.example.com:3128"))
+	transport := c.httpClient.Transport.(*http.Transport)
+	if transport == defaultTransport || defaultTransport.TLSClientConfig != defaultTLSConfig {
+		t.Fatal("expected a transport of the client's own, leaving http.DefaultTransport unchanged")
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 || !transport.TLSClientConfig.InsecureSkipVerify || !transport.ForceAttemptHTTP2 || transport.DisableCompression || transport.DisableKeepAlives {
+		t.Errorf("expected a transport with TLS 1.2 or later, HTTP/2, gzip and keep-alive, got %+v", transport)
+	}
+	if other := NewClient("https://127.0.0.1", "token").httpClient.Transport.(*http.Transport); other.TLSClientConfig.InsecureSkipVerify || other.Proxy == nil {
+		t.Error("expected the settings of a client not to leak to another client")
+	}
+}
+
+func TestTransportReusesConnections(t *testing.T) {
+	var connections, gzipped atomic.Int32
+	var protocols sync.Map
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protocols.Store(r.Proto, true)
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write([]byte(`{"fabricId": "f1"}`))
+			return
+		}
+		gzipped.Add(1)
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		writer.Write([]byte(`{"fabricId": "f1"}`))
+		writer.Close()
+	}))
+	server.EnableHTTP2 = true
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	c := NewClient(server.URL, "token", Insecure(true), MaxRetries(0))
+	if _, err := c.Fabrics.Get(context.Background(), "f1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 49; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if fabric, err := c.Fabrics.Get(context.Background(), "f1"); err != nil || fabric.FabricId != "f1" {
+				t.Errorf("unexpected response %v: %v", fabric, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if connections.Load() != 1 {
+		t.Errorf("expected the requests to share a connection, got %d connections", connections.Load())
+	}
+	if _, ok := protocols.Load("HTTP/2.0"); !ok {
+		t.Error("expected the requests to use HTTP/2")
+	}
+	if gzipped.Load() != 50 {
+		t.Errorf("expected the responses to be requested gzip compressed, got %d", gzipped.Load())
+	}
+}
@@ -0,0 +1,53 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// User is a user of the Hyperfabric organization.
+type User struct {
+	Id        string    `json:"id,omitempty"`
+	Email     *string   `json:"email,omitempty"`
+	Provider  *string   `json:"provider,omitempty"`
+	LastLogin *string   `json:"lastLogin,omitempty"`
+	Enabled   *bool     `json:"enabled,omitempty"`
+	Role      *string   `json:"role,omitempty"`
+	Metadata  *Metadata `json:"metadata,omitempty"`
+	Labels    []string  `json:"labels"`
+}
+
+// MarshalJSON omits the nil lists of the user from its JSON payload, its empty lists are sent to clear them.
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	return marshalWithoutNilLists(user(u))
+}
+
+// UsersService handles the /api/v1/users endpoints.
+type UsersService service
+
+// Get returns the user identified by its id or email, or nil when it does not exist.
+func (s *UsersService) Get(ctx context.Context, userId string) (*User, error) {
+	return getObject[User](ctx, s.client, fmt.Sprintf("/api/v1/users/%s", userId))
+}
+
+// Create creates a user and returns it as returned by the Hyperfabric service.
+func (s *UsersService) Create(ctx context.Context, user *User) (*User, error) {
+	return createObject(ctx, s.client, "POST", "/api/v1/users", "users", user)
+}
+
+// Update replaces the user identified by userId.
+func (s *UsersService) Update(ctx context.Context, userId string, user *User) (*User, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/users/%s", userId), user)
+}
+
+// Delete deletes the user identified by userId.
+func (s *UsersService) Delete(ctx context.Context, userId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/users/%s", userId))
+}
@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Vni is a VXLAN network identifier of a fabric.
+type Vni struct {
+	Id          string       `json:"id,omitempty"`
+	FabricId    string       `json:"fabricId,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Enabled     *bool        `json:"enabled,omitempty"`
+	IsDefault   *bool        `json:"isDefault,omitempty"`
+	VrfId       *string      `json:"vrfId,omitempty"`
+	Vni         *float64     `json:"vni,omitempty"`
+	Mtu         *float64     `json:"mtu,omitempty"`
+	Members     []Member     `json:"members"`
+	Svis        []Svi        `json:"svis"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+	Labels      []string     `json:"labels"`
+	Annotations []Annotation `json:"annotations"`
+}
+
+// MarshalJSON omits the nil lists of the VNI from its JSON payload, its empty lists are sent to clear them.
+func (v Vni) MarshalJSON() ([]byte, error) {
+	type vni Vni
+	return marshalWithoutNilLists(vni(v))
+}
+
+// VnisService handles the /api/v1/fabrics/{fabricId}/vnis endpoints.
+type VnisService service
+
+// Get returns the VNI identified by its id or name, or nil when it does not exist.
+func (s *VnisService) Get(ctx context.Context, fabricId, vniId string) (*Vni, error) {
+	return getObject[Vni](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/vnis/%s", fabricId, vniId))
+}
+
+// Create creates a VNI in a fabric and returns it as returned by the Hyperfabric service.
+func (s *VnisService) Create(ctx context.Context, fabricId string, vni *Vni) (*Vni, error) {
+	return createObject(ctx, s.client, "POST", fmt.Sprintf("/api/v1/fabrics/%s/vnis", fabricId), "vnis", vni)
+}
+
+// Update replaces the VNI identified by vniId.
+func (s *VnisService) Update(ctx context.Context, fabricId, vniId string, vni *Vni) (*Vni, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/vnis/%s", fabricId, vniId), vni)
+}
+
+// Delete deletes the VNI identified by vniId.
+func (s *VnisService) Delete(ctx context.Context, fabricId, vniId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/vnis/%s", fabricId, vniId))
+}
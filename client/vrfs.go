@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Vrf is a virtual routing and forwarding instance of a fabric.
+type Vrf struct {
+	Id          string       `json:"id,omitempty"`
+	FabricId    string       `json:"fabricId,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Enabled     *bool        `json:"enabled,omitempty"`
+	IsDefault   *bool        `json:"isDefault,omitempty"`
+	Asn         *float64     `json:"asn,omitempty"`
+	Vni         *float64     `json:"vni,omitempty"`
+	RouteTarget *string      `json:"routeTarget,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+	Labels      []string     `json:"labels"`
+	Annotations []Annotation `json:"annotations"`
+}
+
+// MarshalJSON omits the nil lists of the VRF from its JSON payload, its empty lists are sent to clear them.
+func (v Vrf) MarshalJSON() ([]byte, error) {
+	type vrf Vrf
+	return marshalWithoutNilLists(vrf(v))
+}
+
+// VrfsService handles the /api/v1/fabrics/{fabricId}/vrfs endpoints.
+type VrfsService service
+
+// Get returns the VRF identified by its id or name, or nil when it does not exist.
+func (s *VrfsService) Get(ctx context.Context, fabricId, vrfId string) (*Vrf, error) {
+	return getObject[Vrf](ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/vrfs/%s", fabricId, vrfId))
+}
+
+// Create creates a VRF in a fabric and returns it as returned by the Hyperfabric service.
+func (s *VrfsService) Create(ctx context.Context, fabricId string, vrf *Vrf) (*Vrf, error) {
+	return createObject(ctx, s.client, "POST", fmt.Sprintf("/api/v1/fabrics/%s/vrfs", fabricId), "vrfs", vrf)
+}
+
+// Update replaces the VRF identified by vrfId.
+func (s *VrfsService) Update(ctx context.Context, fabricId, vrfId string, vrf *Vrf) (*Vrf, error) {
+	return updateObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/vrfs/%s", fabricId, vrfId), vrf)
+}
+
+// Delete deletes the VRF identified by vrfId.
+func (s *VrfsService) Delete(ctx context.Context, fabricId, vrfId string) error {
+	return deleteObject(ctx, s.client, fmt.Sprintf("/api/v1/fabrics/%s/vrfs/%s", fabricId, vrfId))
+}
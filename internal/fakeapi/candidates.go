@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// Commit is a commit of a candidate configuration of a fabric received by the Server.
+type Commit struct {
+	FabricId  string
+	Candidate string
+	Comments  string
+}
+
+// Commits returns the commits of the candidate configurations of the fabric identified by its id or name, in their
+// order of reception.
+func (s *Server) Commits(fabricId string) []Commit {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	index := s.find("/api/v1/fabrics", rootCollections["fabrics"], fabricId)
+	if index >= 0 {
+		fabricId = s.collections["/api/v1/fabrics"][index]["fabricId"].(string)
+	}
+	var commits []Commit
+	for _, commit := range s.commits {
+		if commit.FabricId == fabricId {
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// candidateChange is a pending change of an object of a fabric in a candidate configuration.
+type candidateChange struct {
+	ObjectType string   `json:"objectType"`
+	ObjectId   string   `json:"objectId"`
+	Operation  string   `json:"operation"`
+	Fields     []string `json:"fields,omitempty"`
+}
+
+// recordChange records the change of the object with objectId in the collection at path in the candidate configuration
+// of its fabric. The changes of an object are merged until they are committed: an update of a created object is part
+// of its creation and the deletion of a created object cancels its creation. Changes outside of a fabric are ignored.
+func (s *Server) recordChange(path string, spec *collectionSpec, objectId, operation string, fields []string) {
+	key, ok := parentIds(path)["fabricId"]
+	if !ok || (operation == "UPDATE" && len(fields) == 0) {
+		return
+	}
+	if s.candidates == nil {
+		s.candidates = map[string][]*candidateChange{}
+	}
+	for i, change := range s.candidates[key] {
+		if change.ObjectType != spec.name || change.ObjectId != objectId {
+			continue
+		}
+		switch {
+		case operation == "DELETE" && change.Operation == "CREATE":
+			s.candidates[key] = append(s.candidates[key][:i:i], s.candidates[key][i+1:]...)
+		case operation == "DELETE":
+			change.Operation = operation
+			change.Fields = nil
+		default:
+			change.Fields = mergeFields(change.Fields, fields)
+		}
+		return
+	}
+	s.candidates[key] = append(s.candidates[key], &candidateChange{ObjectType: spec.name, ObjectId: objectId, Operation: operation, Fields: mergeFields(nil, fields)})
+}
+
+// changedFields returns the attributes of after which differ from the attributes of before, without the metadata.
+func changedFields(before, after object) []string {
+	var fields []string
+	for key, value := range after {
+		if key != "metadata" && !reflect.DeepEqual(before[key], value) {
+			fields = append(fields, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok && key != "metadata" {
+			fields = append(fields, key)
+		}
+	}
+	return fields
+}
+
+// mergeFields returns the sorted union of fields and others.
+func mergeFields(fields, others []string) []string {
+	merged := append([]string{}, fields...)
+	for _, field := range others {
+		if !contains(merged, field) {
+			merged = append(merged, field)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// serveCandidate serves the candidate configuration of a fabric: /fabrics/{fabricId}/candidates/{name}. The fake
+// stages the changes of a fabric in a single candidate configuration, whatever its name.
+func (s *Server) serveCandidate(w http.ResponseWriter, r *http.Request, segments []string, body map[string]json.RawMessage) {
+	fabricIndex := s.find("/api/v1/fabrics", rootCollections["fabrics"], segments[1])
+	if fabricIndex < 0 {
+		writeError(w, http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("fabric %s not found", segments[1]), "")
+		return
+	}
+	if len(segments) != 4 {
+		writeError(w, http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("unknown path %s", r.URL.Path), "")
+		return
+	}
+
+	fabric := s.collections["/api/v1/fabrics"][fabricIndex]
+	key := fabric["fabricId"].(string)
+	switch r.Method {
+	case http.MethodGet:
+		changes := s.candidates[key]
+		if changes == nil {
+			changes = []*candidateChange{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"fabricId": fabric["fabricId"], "name": segments[3], "changes": changes})
+	case http.MethodPost:
+		var comments string
+		if raw, ok := body["comments"]; ok {
+			if err := json.Unmarshal(raw, &comments); err != nil {
+				writeError(w, http.StatusBadRequest, "ERR_CODE_BAD_REQUEST", fmt.Sprintf("invalid comments: %v", err), "comments")
+				return
+			}
+		}
+		commit := Commit{FabricId: fabric["fabricId"].(string), Candidate: segments[3], Comments: comments}
+		s.commits = append(s.commits, commit)
+		delete(s.candidates, key)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"fabricId": commit.FabricId, "name": commit.Candidate, "comments": commit.Comments})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "ERR_CODE_METHOD_NOT_ALLOWED", fmt.Sprintf("%s is not allowed on %s", r.Method, r.URL.Path), "")
+	}
+}
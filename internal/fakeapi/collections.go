@@ -0,0 +1,295 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+)
+
+// collectionSpec describes the behavior of a collection of the Hyperfabric API.
+type collectionSpec struct {
+	// name is the name of the objects in error messages.
+	name string
+	// envelope is the key of the list of objects in the payload of a create and in the list response.
+	envelope string
+	// idField is the attribute holding the generated id of the objects.
+	idField string
+	// nameField is the attribute by which objects can also be addressed, name when empty.
+	nameField string
+	// defaults are the attribute values of an object when they are not in the payload of a create or update.
+	defaults map[string]interface{}
+	// required are the attributes that must be in the payload of a create or update.
+	required []string
+	// uniqueNames rejects a name already used by another object of the collection.
+	uniqueNames bool
+	// readOnly are the attributes managed by the service, which are kept on update.
+	readOnly []string
+	// resetOnDelete resets the object to its defaults on delete, for objects that belong to their parent.
+	resetOnDelete bool
+	children      map[string]*collectionSpec
+
+	validate         func(s *Server, path string, payload object) *apiError
+	normalize        func(s *Server, path string, obj object)
+	onCreate         func(s *Server, objectPath string, obj object)
+	onCreateResponse func(created []object, response map[string]interface{})
+	onDelete         func(s *Server, objectPath string, obj object)
+}
+
+var rootCollections map[string]*collectionSpec
+
+func init() {
+	rootCollections = map[string]*collectionSpec{
+		"fabrics": {
+			name:        "fabric",
+			envelope:    "fabrics",
+			idField:     "fabricId",
+			required:    []string{"name"},
+			uniqueNames: true,
+			defaults: map[string]interface{}{
+				"topology": "MESH",
+			},
+			children: map[string]*collectionSpec{
+				"nodes":       nodesCollection,
+				"connections": connectionsCollection,
+				"vnis":        vnisCollection,
+				"vrfs":        vrfsCollection,
+			},
+		},
+		"devices": {
+			name:     "device",
+			envelope: "devices",
+			idField:  "deviceId",
+			validate: func(s *Server, path string, payload object) *apiError {
+				return newAPIError(http.StatusMethodNotAllowed, "ERR_CODE_METHOD_NOT_ALLOWED", "devices are managed by the Hyperfabric service", "")
+			},
+		},
+		"users": {
+			name:      "user",
+			envelope:  "users",
+			idField:   "id",
+			nameField: "email",
+			readOnly:  []string{"email", "provider", "lastLogin"},
+			defaults: map[string]interface{}{
+				"enabled":  true,
+				"provider": "USER_PROVIDER_LOCAL",
+				"role":     "READ_ONLY",
+			},
+			validate: func(s *Server, path string, payload object) *apiError {
+				email, ok := payload["email"].(string)
+				if !ok {
+					return nil
+				}
+				for _, user := range s.collections[path] {
+					if user["email"] == email {
+						return newAPIError(http.StatusConflict, "ERR_CODE_ALREADY_EXISTS", fmt.Sprintf("user %s already exists", email), "email")
+					}
+				}
+				return nil
+			},
+		},
+		"bearerTokens": {
+			name:     "bearer token",
+			envelope: "tokens",
+			idField:  "tokenId",
+			required: []string{"name"},
+			defaults: map[string]interface{}{
+				"scope": "TOKEN_SCOPE_ADMIN",
+			},
+			normalize: func(s *Server, path string, obj object) {
+				delete(obj, "token")
+				if _, ok := obj["notBefore"]; !ok {
+					obj["notBefore"] = nowRFC3339()
+				}
+				if _, ok := obj["notAfter"]; !ok {
+					obj["notAfter"] = yearFromNowRFC3339()
+				}
+			},
+			// The token is only returned in the response of the create.
+			onCreateResponse: func(created []object, response map[string]interface{}) {
+				response["token"] = "hf." + newUUID()
+			},
+		},
+	}
+}
+
+var nodesCollection = &collectionSpec{
+	name:        "node",
+	envelope:    "nodes",
+	idField:     "nodeId",
+	required:    []string{"name", "modelName"},
+	uniqueNames: true,
+	readOnly:    []string{"deviceId", "serialNumber"},
+	defaults: map[string]interface{}{
+		"enabled":      true,
+		"roles":        []interface{}{"LEAF"},
+		"deviceId":     "",
+		"serialNumber": "",
+	},
+	onCreate: func(s *Server, objectPath string, obj object) {
+		ports := 32
+		if obj["modelName"] == "HF6100-60L4D" {
+			ports = 64
+		}
+		portsPath := objectPath + "/ports"
+		for i := 1; i <= ports; i++ {
+			port := s.newObject(portsPath, portsCollection, object{})
+			port["id"] = newUUID()
+			port["name"] = fmt.Sprintf("Ethernet1_%d", i)
+			port["index"] = float64(i)
+			port["linecard"] = float64(1)
+			port["maxSpeed"] = "400G"
+			port["speed"] = "400G"
+			port["metadata"] = newMetadata()
+			s.collections[portsPath] = append(s.collections[portsPath], port)
+		}
+	},
+	onDelete: func(s *Server, objectPath string, obj object) {
+		s.unbindDevice(obj)
+	},
+	children: map[string]*collectionSpec{
+		"ports":           portsCollection,
+		"loopbacks":       loopbacksCollection,
+		"subInterfaces":   subInterfacesCollection,
+		"managementPorts": managementPortsCollection,
+	},
+}
+
+var portsCollection = &collectionSpec{
+	name:          "port",
+	envelope:      "ports",
+	idField:       "id",
+	readOnly:      []string{"name", "index", "linecard", "maxSpeed", "speed"},
+	resetOnDelete: true,
+	defaults: map[string]interface{}{
+		"roles": []interface{}{"UNUSED_PORT"},
+	},
+}
+
+var loopbacksCollection = &collectionSpec{
+	name:        "loopback",
+	envelope:    "loopbacks",
+	idField:     "id",
+	required:    []string{"name"},
+	uniqueNames: true,
+}
+
+var subInterfacesCollection = &collectionSpec{
+	name:        "sub-interface",
+	envelope:    "subInterfaces",
+	idField:     "id",
+	required:    []string{"name"},
+	uniqueNames: true,
+	normalize: func(s *Server, path string, obj object) {
+		// The parent port of a sub-interface is the part of its name before the dot, such as Ethernet1_1 for Ethernet1_1.100.
+		if name, ok := obj["name"].(string); ok {
+			for i := len(name) - 1; i >= 0; i-- {
+				if name[i] == '.' {
+					obj["parent"] = name[:i]
+					break
+				}
+			}
+		}
+	},
+}
+
+var managementPortsCollection = &collectionSpec{
+	name:     "management port",
+	envelope: "ports",
+	idField:  "id",
+	defaults: map[string]interface{}{
+		"name":           "eth0",
+		"enabled":        true,
+		"ipv4ConfigType": "CONFIG_TYPE_DHCP",
+		"ipv6ConfigType": "CONFIG_TYPE_DHCP",
+		"configOrigin":   "CONFIG_ORIGIN_CLOUD",
+		"connectedState": "CONNECTED_STATE_NOT_CONNECTED",
+	},
+	normalize: func(s *Server, path string, obj object) {
+		// The proxy password is write-only.
+		delete(obj, "proxyPassword")
+		delete(obj, "setProxyPassword")
+	},
+}
+
+var connectionsCollection = &collectionSpec{
+	name:     "connection",
+	envelope: "connections",
+	idField:  "id",
+	required: []string{"local", "remote"},
+	normalize: func(s *Server, path string, obj object) {
+		for _, key := range []string{"local", "remote"} {
+			if endpoint, ok := obj[key].(map[string]interface{}); ok {
+				if name := s.nodeName(path, endpoint["nodeId"]); name != "" {
+					endpoint["nodeName"] = name
+				}
+			}
+		}
+	},
+}
+
+var vnisCollection = &collectionSpec{
+	name:        "VNI",
+	envelope:    "vnis",
+	idField:     "id",
+	required:    []string{"name"},
+	uniqueNames: true,
+	defaults: map[string]interface{}{
+		"isDefault": false,
+	},
+	// The VNI number is allocated by the service when it is not in the payload.
+	onCreate: func(s *Server, objectPath string, obj object) {
+		if _, ok := obj["vni"]; ok {
+			return
+		}
+		vni := float64(10000)
+		for _, other := range s.collections[path.Dir(objectPath)] {
+			if number, ok := other["vni"].(float64); ok && number >= vni {
+				vni = number + 1
+			}
+		}
+		obj["vni"] = vni
+	},
+	normalize: func(s *Server, path string, obj object) {
+		if members, ok := obj["members"].([]interface{}); ok {
+			for _, member := range members {
+				if port, ok := member.(map[string]interface{})["port"].(map[string]interface{}); ok {
+					if name := s.nodeName(path, port["nodeId"]); name != "" {
+						port["nodeName"] = name
+					}
+				}
+			}
+		}
+	},
+}
+
+var vrfsCollection = &collectionSpec{
+	name:        "VRF",
+	envelope:    "vrfs",
+	idField:     "id",
+	required:    []string{"name"},
+	uniqueNames: true,
+	defaults: map[string]interface{}{
+		"isDefault": false,
+	},
+}
+
+// nodeName returns the name of the node identified by nodeId in the fabric of the collection at path.
+func (s *Server) nodeName(path string, nodeId interface{}) string {
+	fabricId, ok := parentIds(path)["fabricId"]
+	id, isString := nodeId.(string)
+	if !ok || !isString {
+		return ""
+	}
+	nodesPath := fmt.Sprintf("/api/v1/fabrics/%s/nodes", fabricId)
+	if index := s.find(nodesPath, nodesCollection, id); index >= 0 {
+		name, _ := s.collections[nodesPath][index]["name"].(string)
+		return name
+	}
+	return ""
+}
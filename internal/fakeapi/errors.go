@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// apiError is the RestError body returned by the Hyperfabric service on failure.
+type apiError struct {
+	Status     int      `json:"status"`
+	ErrCode    string   `json:"errCode"`
+	Message    string   `json:"message"`
+	Field      string   `json:"field,omitempty"`
+	Causes     []string `json:"causes,omitempty"`
+	TrackingId string   `json:"trackingId"`
+}
+
+func newAPIError(status int, errCode, message, field string) *apiError {
+	return &apiError{
+		Status:     status,
+		ErrCode:    errCode,
+		Message:    message,
+		Field:      field,
+		TrackingId: newUUID(),
+	}
+}
+
+func (e *apiError) write(w http.ResponseWriter) {
+	writeJSON(w, e.Status, e)
+}
+
+func writeError(w http.ResponseWriter, status int, errCode, message, field string) {
+	newAPIError(status, errCode, message, field).write(w)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(omitDefaults(body))
+}
+
+// omitDefaults returns a copy of value without the attributes set to false, which the Hyperfabric service omits from
+// its JSON responses like the other attributes holding the default value of their type.
+func omitDefaults(value interface{}) interface{} {
+	switch v := value.(type) {
+	case object:
+		return omitDefaults(map[string]interface{}(v))
+	case []object:
+		copied := make([]interface{}, len(v))
+		for i, obj := range v {
+			copied[i] = omitDefaults(obj)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, value := range v {
+			copied[i] = omitDefaults(value)
+		}
+		return copied
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if value != false {
+				copied[key] = omitDefaults(value)
+			}
+		}
+		return copied
+	}
+	return value
+}
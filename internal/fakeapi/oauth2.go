@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"net/http"
+)
+
+// TokenPath is the path of the OAuth2 token endpoint of a Server, which issues the Token of the server for the client
+// credentials DefaultClientId and DefaultClientSecret.
+const TokenPath = "/oauth2/token"
+
+// DefaultClientId and DefaultClientSecret are the OAuth2 client credentials accepted by the token endpoint of a Server.
+const (
+	DefaultClientId     = "fake-hyperfabric-client"
+	DefaultClientSecret = "fake-hyperfabric-client-secret"
+)
+
+// serveToken serves the client credentials grant of the OAuth2 token endpoint.
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"error": "invalid_request"})
+		return
+	}
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientId, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if r.PostFormValue("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "unsupported_grant_type"})
+		return
+	}
+	if clientId != DefaultClientId || clientSecret != DefaultClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": "invalid_client", "error_description": "unknown client or invalid secret"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"access_token": s.Token, "token_type": "Bearer", "expires_in": 3600})
+}
@@ -0,0 +1,549 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakeapi provides an in-memory stand-in of the Hyperfabric REST API for offline tests.
+//
+// The fake implements the endpoints of the fabrics and their nodes, ports, management ports, loopbacks,
+// sub-interfaces, connections, VNIs and VRFs, as well as the devices, users and bearer tokens of the
+// organization, and an OAuth2 token endpoint issuing the bearer token of the server. The changes made to the
+// objects of a fabric are pending in its candidate configuration until they are committed. Objects are identified
+// by generated UUIDs or by their name, get metadata on create and update, and errors are returned with the
+// RestError body of the Hyperfabric service.
+package fakeapi
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultToken is the bearer token accepted by a Server created with NewServer.
+const DefaultToken = "fake-hyperfabric-token"
+
+// Server is an in-memory Hyperfabric REST API served over TLS by an httptest.Server.
+type Server struct {
+	*httptest.Server
+	// Token is the bearer token expected in the Authorization header of every request.
+	Token string
+
+	lock sync.Mutex
+	// collections holds the objects of every collection by the path of the collection, such as
+	// /api/v1/fabrics/{fabricId}/nodes, in their order of creation.
+	collections map[string][]object
+	// candidates holds the pending changes of the candidate configurations by fabricId.
+	candidates map[string][]*candidateChange
+	commits    []Commit
+	requests   []Request
+}
+
+// Request is a request served by a Server, see RequestLog.
+type Request struct {
+	Method string
+	Path   string
+	// Objects is the number of objects created by a POST request to a collection.
+	Objects int
+}
+
+type object map[string]interface{}
+
+// NewServer starts a Server with an organization holding the devices of DefaultDevices.
+// Clients of the server must skip the verification of its certificate, see httptest.NewTLSServer.
+func NewServer() *Server {
+	s := &Server{
+		Token:       DefaultToken,
+		collections: make(map[string][]object),
+	}
+	for _, device := range DefaultDevices {
+		s.AddDevice(device.DeviceId, device.SerialNumber, device.ModelName)
+	}
+	s.Server = httptest.NewTLSServer(s)
+	return s
+}
+
+// DefaultDevices are the devices of the organization of a new Server, which can be bound to nodes.
+var DefaultDevices = []struct {
+	DeviceId     string
+	SerialNumber string
+	ModelName    string
+}{
+	{"2b5c4e1a-6b7d-4f8e-9a0b-1c2d3e4f5a6b", "FDO00000001", "HF6100-32D"},
+	{"7e8f9a0b-1c2d-4e3f-8a5b-6c7d8e9f0a1b", "FDO00000002", "HF6100-60L4D"},
+}
+
+// AddDevice adds an unbound device to the organization.
+func (s *Server) AddDevice(deviceId, serialNumber, modelName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.collections["/api/v1/devices"] = append(s.collections["/api/v1/devices"], object{
+		"deviceId":     deviceId,
+		"serialNumber": serialNumber,
+		"modelName":    modelName,
+		"osType":       "OS_TYPE_NEXUS",
+		"roles":        []interface{}{},
+	})
+}
+
+// Requests returns the number of requests served.
+func (s *Server) Requests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.requests)
+}
+
+// RequestLog returns the requests served, in the order they were received.
+func (s *Server) RequestLog() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+
+	if r.URL.Path == TokenPath {
+		s.serveToken(w, r)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "ERR_CODE_UNAUTHENTICATED", "invalid or missing bearer token", "")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/api/v1/") {
+		writeError(w, http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("unknown path %s", r.URL.Path), "")
+		return
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1"), "/"), "/")
+
+	var body map[string]json.RawMessage
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err.Error() != "EOF" {
+				writeError(w, http.StatusBadRequest, "ERR_CODE_BAD_REQUEST", fmt.Sprintf("invalid JSON payload: %v", err), "")
+				return
+			}
+		}
+	}
+
+	// Binding of devices to nodes: /fabrics/{fabricId}/nodes/{nodeId}/devices[/{deviceId}]
+	if len(segments) >= 5 && segments[0] == "fabrics" && segments[2] == "nodes" && segments[4] == "devices" {
+		s.serveDeviceBinding(w, r, segments)
+		return
+	}
+
+	// Candidate configurations of fabrics: /fabrics/{fabricId}/candidates/{name}
+	if len(segments) >= 3 && segments[0] == "fabrics" && segments[2] == "candidates" {
+		s.serveCandidate(w, r, segments, body)
+		return
+	}
+
+	path, spec, id, apiErr := s.resolve(segments)
+	if apiErr != nil {
+		apiErr.write(w)
+		return
+	}
+
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{spec.envelope: s.list(path)})
+		case http.MethodPost:
+			s.create(w, path, spec, body)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "ERR_CODE_METHOD_NOT_ALLOWED", fmt.Sprintf("%s is not allowed on %s", r.Method, r.URL.Path), "")
+		}
+		return
+	}
+
+	index := s.find(path, spec, id)
+	if index < 0 {
+		writeError(w, http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("%s %s not found", spec.name, id), "")
+		return
+	}
+	if apiErr := checkRevision(r, s.collections[path][index], spec); apiErr != nil {
+		apiErr.write(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.collections[path][index])
+	case http.MethodPut:
+		s.update(w, path, spec, index, body)
+	case http.MethodDelete:
+		s.delete(path, spec, index)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "ERR_CODE_METHOD_NOT_ALLOWED", fmt.Sprintf("%s is not allowed on %s", r.Method, r.URL.Path), "")
+	}
+}
+
+// resolve returns the path of the collection addressed by segments, its spec and the id of the addressed object,
+// which is empty when the collection itself is addressed. The objects of the path are resolved by id or name.
+func (s *Server) resolve(segments []string) (string, *collectionSpec, string, *apiError) {
+	path := "/api/v1"
+	specs := rootCollections
+	for i := 0; i < len(segments); i += 2 {
+		spec, ok := specs[segments[i]]
+		if !ok {
+			return "", nil, "", newAPIError(http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("unknown collection %s", segments[i]), "")
+		}
+		path = path + "/" + segments[i]
+		if i+1 == len(segments) {
+			return path, spec, "", nil
+		}
+		if i+2 == len(segments) {
+			return path, spec, segments[i+1], nil
+		}
+		index := s.find(path, spec, segments[i+1])
+		if index < 0 {
+			return "", nil, "", newAPIError(http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("%s %s not found", spec.name, segments[i+1]), "")
+		}
+		path = path + "/" + s.collections[path][index][spec.idField].(string)
+		specs = spec.children
+	}
+	return path, nil, "", newAPIError(http.StatusNotFound, "ERR_CODE_NOT_FOUND", "unknown path", "")
+}
+
+// find returns the index of the object identified by its id or name in the collection at path, or -1.
+func (s *Server) find(path string, spec *collectionSpec, id string) int {
+	for i, obj := range s.collections[path] {
+		if obj[spec.idField] == id {
+			return i
+		}
+	}
+	nameField := spec.nameField
+	if nameField == "" {
+		nameField = "name"
+	}
+	for i, obj := range s.collections[path] {
+		if name, ok := obj[nameField].(string); ok && name == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) list(path string) []object {
+	objects := s.collections[path]
+	if objects == nil {
+		return []object{}
+	}
+	return objects
+}
+
+func (s *Server) create(w http.ResponseWriter, path string, spec *collectionSpec, body map[string]json.RawMessage) {
+	var payloads []object
+	if raw, ok := body[spec.envelope]; ok {
+		if err := json.Unmarshal(raw, &payloads); err != nil {
+			writeError(w, http.StatusBadRequest, "ERR_CODE_BAD_REQUEST", fmt.Sprintf("invalid %s: %v", spec.envelope, err), spec.envelope)
+			return
+		}
+	}
+	if len(payloads) == 0 {
+		writeError(w, http.StatusBadRequest, "ERR_CODE_BAD_REQUEST", fmt.Sprintf("at least one object is required in %s", spec.envelope), spec.envelope)
+		return
+	}
+	s.requests[len(s.requests)-1].Objects = len(payloads)
+
+	created := make([]object, 0, len(payloads))
+	for i, payload := range payloads {
+		if apiErr := s.validate(path, spec, payload, -1); apiErr != nil {
+			apiErr.Field = fmt.Sprintf("%s[%d].%s", spec.envelope, i, apiErr.Field)
+			apiErr.write(w)
+			return
+		}
+	}
+	for _, payload := range payloads {
+		obj := s.newObject(path, spec, payload)
+		obj[spec.idField] = newUUID()
+		obj["metadata"] = newMetadata()
+		s.collections[path] = append(s.collections[path], obj)
+		s.recordChange(path, spec, obj[spec.idField].(string), "CREATE", changedFields(object{}, payload))
+		if spec.onCreate != nil {
+			spec.onCreate(s, path+"/"+obj[spec.idField].(string), obj)
+		}
+		created = append(created, obj)
+	}
+
+	response := map[string]interface{}{spec.envelope: created}
+	if spec.onCreateResponse != nil {
+		spec.onCreateResponse(created, response)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) update(w http.ResponseWriter, path string, spec *collectionSpec, index int, body map[string]json.RawMessage) {
+	payload := object{}
+	for key, raw := range body {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			writeError(w, http.StatusBadRequest, "ERR_CODE_BAD_REQUEST", fmt.Sprintf("invalid %s: %v", key, err), key)
+			return
+		}
+		payload[key] = value
+	}
+	if apiErr := s.validate(path, spec, payload, index); apiErr != nil {
+		apiErr.write(w)
+		return
+	}
+
+	current := s.collections[path][index]
+	obj := s.newObject(path, spec, payload)
+	// The identity of the object and the attributes managed by the service are kept.
+	obj[spec.idField] = current[spec.idField]
+	for _, key := range spec.readOnly {
+		if value, ok := current[key]; ok {
+			obj[key] = value
+		}
+	}
+	obj["metadata"] = updatedMetadata(current["metadata"])
+	s.collections[path][index] = obj
+	s.recordChange(path, spec, obj[spec.idField].(string), "UPDATE", changedFields(current, obj))
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (s *Server) delete(path string, spec *collectionSpec, index int) {
+	current := s.collections[path][index]
+	objectPath := path + "/" + current[spec.idField].(string)
+	s.recordChange(path, spec, current[spec.idField].(string), "DELETE", nil)
+	// Ports belong to the node and are reset to their defaults instead of being deleted.
+	if spec.resetOnDelete {
+		obj := s.newObject(path, spec, object{})
+		obj[spec.idField] = current[spec.idField]
+		for _, key := range spec.readOnly {
+			if value, ok := current[key]; ok {
+				obj[key] = value
+			}
+		}
+		obj["metadata"] = updatedMetadata(current["metadata"])
+		s.collections[path][index] = obj
+		return
+	}
+	if spec.onDelete != nil {
+		spec.onDelete(s, objectPath, current)
+	}
+	s.collections[path] = append(s.collections[path][:index:index], s.collections[path][index+1:]...)
+	for collectionPath := range s.collections {
+		if strings.HasPrefix(collectionPath, objectPath+"/") {
+			delete(s.collections, collectionPath)
+		}
+	}
+}
+
+// UpdateObject changes the attributes of the object at path, such as /api/v1/fabrics/{fabricId}, as a user of
+// the Hyperfabric service would outside of the client under test. It returns false when the object does not exist.
+func (s *Server) UpdateObject(path string, attributes map[string]interface{}) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/v1"), "/"), "/")
+	collectionPath, spec, id, apiErr := s.resolve(segments)
+	if apiErr != nil || id == "" {
+		return false
+	}
+	index := s.find(collectionPath, spec, id)
+	if index < 0 {
+		return false
+	}
+	obj := s.collections[collectionPath][index]
+	for key, value := range attributes {
+		obj[key] = value
+	}
+	obj["metadata"] = updatedMetadata(obj["metadata"])
+	return true
+}
+
+// checkRevision rejects a PUT or DELETE request with an If-Match header that does not match the revision of obj.
+func checkRevision(r *http.Request, obj object, spec *collectionSpec) *apiError {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || (r.Method != http.MethodPut && r.Method != http.MethodDelete) {
+		return nil
+	}
+	var revisionId string
+	if metadata, ok := obj["metadata"].(object); ok {
+		revisionId = fmt.Sprint(metadata["revisionId"])
+	}
+	if strings.Trim(ifMatch, `"`) == revisionId {
+		return nil
+	}
+	return newAPIError(http.StatusPreconditionFailed, "ERR_CODE_PRECONDITION_FAILED", fmt.Sprintf("%s %s was modified since revision %s, its current revision is %s", spec.name, obj[spec.idField], ifMatch, revisionId), "")
+}
+
+// newObject returns the object stored for payload: the defaults of the collection, overridden by the payload,
+// and the ids of the parents of the collection.
+func (s *Server) newObject(path string, spec *collectionSpec, payload object) object {
+	obj := object{}
+	for key, value := range spec.defaults {
+		obj[key] = copyValue(value)
+	}
+	for key, value := range payload {
+		if value == nil || key == "metadata" {
+			continue
+		}
+		obj[key] = value
+	}
+	for key, value := range parentIds(path) {
+		obj[key] = value
+	}
+	if spec.normalize != nil {
+		spec.normalize(s, path, obj)
+	}
+	return obj
+}
+
+// validate checks the required attributes and the uniqueness of the name of payload, index is the index of the
+// updated object or -1 on create.
+func (s *Server) validate(path string, spec *collectionSpec, payload object, index int) *apiError {
+	for _, key := range spec.required {
+		if value, ok := payload[key]; !ok || value == "" {
+			return newAPIError(http.StatusBadRequest, "ERR_CODE_BAD_REQUEST", fmt.Sprintf("%s is required", key), key)
+		}
+	}
+	if name, ok := payload["name"].(string); ok && name != "" && spec.uniqueNames {
+		for i, obj := range s.collections[path] {
+			if i != index && obj["name"] == name {
+				return newAPIError(http.StatusConflict, "ERR_CODE_ALREADY_EXISTS", fmt.Sprintf("%s %s already exists", spec.name, name), "name")
+			}
+		}
+	}
+	if spec.validate != nil {
+		return spec.validate(s, path, payload)
+	}
+	return nil
+}
+
+func (s *Server) serveDeviceBinding(w http.ResponseWriter, r *http.Request, segments []string) {
+	nodesPath, nodesSpec, nodeId, apiErr := s.resolve(segments[:4])
+	if apiErr != nil {
+		apiErr.write(w)
+		return
+	}
+	nodeIndex := s.find(nodesPath, nodesSpec, nodeId)
+	if nodeIndex < 0 {
+		writeError(w, http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("node %s not found", nodeId), "")
+		return
+	}
+	node := s.collections[nodesPath][nodeIndex]
+
+	switch {
+	case r.Method == http.MethodPut && len(segments) == 6:
+		deviceIndex := s.find("/api/v1/devices", rootCollections["devices"], segments[5])
+		if deviceIndex < 0 {
+			writeError(w, http.StatusNotFound, "ERR_CODE_NOT_FOUND", fmt.Sprintf("device %s not found", segments[5]), "deviceId")
+			return
+		}
+		device := s.collections["/api/v1/devices"][deviceIndex]
+		if boundNodeId, ok := device["nodeId"].(string); ok && boundNodeId != "" && boundNodeId != node["nodeId"] {
+			writeError(w, http.StatusConflict, "ERR_CODE_ALREADY_EXISTS", fmt.Sprintf("device %s is already bound to node %s", segments[5], boundNodeId), "deviceId")
+			return
+		}
+		s.unbindDevice(node)
+		node["deviceId"] = device["deviceId"]
+		node["serialNumber"] = device["serialNumber"]
+		device["fabricId"] = node["fabricId"]
+		device["nodeId"] = node["nodeId"]
+		s.recordChange(nodesPath, nodesSpec, node[nodesSpec.idField].(string), "UPDATE", []string{"deviceId", "serialNumber"})
+		writeJSON(w, http.StatusOK, node)
+	case r.Method == http.MethodDelete && len(segments) == 5:
+		if deviceId, ok := node["deviceId"].(string); ok && deviceId != "" {
+			s.recordChange(nodesPath, nodesSpec, node[nodesSpec.idField].(string), "UPDATE", []string{"deviceId", "serialNumber"})
+		}
+		s.unbindDevice(node)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "ERR_CODE_METHOD_NOT_ALLOWED", fmt.Sprintf("%s is not allowed on %s", r.Method, r.URL.Path), "")
+	}
+}
+
+func (s *Server) unbindDevice(node object) {
+	deviceId, ok := node["deviceId"].(string)
+	if !ok || deviceId == "" {
+		return
+	}
+	for _, device := range s.collections["/api/v1/devices"] {
+		if device["deviceId"] == deviceId {
+			delete(device, "fabricId")
+			delete(device, "nodeId")
+		}
+	}
+	node["deviceId"] = ""
+	node["serialNumber"] = ""
+}
+
+// parentIds returns the ids of the parents of the collection at path, such as fabricId and nodeId.
+func parentIds(path string) map[string]string {
+	ids := map[string]string{}
+	segments := strings.Split(strings.TrimPrefix(path, "/api/v1/"), "/")
+	for i := 0; i+1 < len(segments); i += 2 {
+		switch segments[i] {
+		case "fabrics":
+			ids["fabricId"] = segments[i+1]
+		case "nodes":
+			ids["nodeId"] = segments[i+1]
+		}
+	}
+	return ids
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+const fakeUser = "terraform@fake.hyperfabric.local"
+
+func newMetadata() object {
+	now := nowRFC3339()
+	return object{
+		"createdAt":  now,
+		"createdBy":  fakeUser,
+		"modifiedAt": now,
+		"modifiedBy": fakeUser,
+		"revisionId": "1",
+	}
+}
+
+func updatedMetadata(current interface{}) object {
+	metadata := newMetadata()
+	if currentMetadata, ok := current.(object); ok {
+		metadata["createdAt"] = currentMetadata["createdAt"]
+		metadata["createdBy"] = currentMetadata["createdBy"]
+		revisionId, _ := strconv.Atoi(fmt.Sprint(currentMetadata["revisionId"]))
+		metadata["revisionId"] = strconv.Itoa(revisionId + 1)
+	}
+	return metadata
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return append([]interface{}{}, v...)
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			copied[key] = copyValue(value)
+		}
+		return copied
+	}
+	return value
+}
+
+func nowRFC3339() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func yearFromNowRFC3339() string {
+	return time.Now().UTC().AddDate(1, 0, 0).Format(time.RFC3339)
+}
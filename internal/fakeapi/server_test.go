@@ -0,0 +1,176 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+)
+
+// newTestClient returns a Server and a client of the server, with the candidate configuration API the server implements.
+func newTestClient(t *testing.T, token string) (*Server, *client.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	return server, client.NewClient(server.URL, token, client.Insecure(true), client.MaxRetries(0), client.ExperimentalCandidateAPI(true))
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func TestFabricLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t, DefaultToken)
+
+	fabric, err := c.Fabrics.Create(ctx, &client.Fabric{Name: stringPointer("fabric1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fabric.FabricId == "" || fabric.Metadata == nil || *fabric.Topology != "MESH" {
+		t.Errorf("expected a generated id, metadata and the default topology, got %+v", fabric)
+	}
+
+	byName, err := c.Fabrics.Get(ctx, "fabric1")
+	if err != nil || byName == nil || byName.FabricId != fabric.FabricId {
+		t.Errorf("expected the fabric by name, got %+v, err: %v", byName, err)
+	}
+
+	_, err = c.Fabrics.Create(ctx, &client.Fabric{Name: stringPointer("fabric1")})
+	var apiErr *client.APIError
+	if !errors.Is(err, client.ErrConflict) || !errors.As(err, &apiErr) || apiErr.RestError.Field != "fabrics[0].name" {
+		t.Errorf("expected a conflict on the name, got %v", err)
+	}
+
+	if err := c.Fabrics.Delete(ctx, fabric.FabricId); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deleted, err := c.Fabrics.Get(ctx, fabric.FabricId)
+	if err != nil || deleted != nil {
+		t.Errorf("expected the fabric to be deleted, got %+v, err: %v", deleted, err)
+	}
+}
+
+func TestNodePortsAndDeviceBinding(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t, DefaultToken)
+
+	fabric, err := c.Fabrics.Create(ctx, &client.Fabric{Name: stringPointer("fabric1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	node, err := c.Nodes.Create(ctx, fabric.FabricId, &client.Node{Name: stringPointer("leaf1"), ModelName: stringPointer("HF6100-32D")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	port, err := c.Ports.Get(ctx, "fabric1", "leaf1", "Ethernet1_32")
+	if err != nil || port == nil || port.Roles[0] != "UNUSED_PORT" {
+		t.Fatalf("expected the unused ports of the node, got %+v, err: %v", port, err)
+	}
+
+	if err := c.Nodes.BindDevice(ctx, fabric.FabricId, node.NodeId, DefaultDevices[0].DeviceId); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bound, err := c.Nodes.Get(ctx, fabric.FabricId, node.NodeId)
+	if err != nil || bound.DeviceId == nil || *bound.DeviceId != DefaultDevices[0].DeviceId {
+		t.Errorf("expected the node to be bound to the device, got %+v, err: %v", bound, err)
+	}
+}
+
+func TestUnauthenticated(t *testing.T) {
+	_, c := newTestClient(t, "wrong-token")
+
+	_, err := c.Fabrics.Get(context.Background(), "fabric1")
+	if !errors.Is(err, client.ErrAuth) {
+		t.Errorf("expected an authentication error, got %v", err)
+	}
+}
+
+func TestRevisionMismatch(t *testing.T) {
+	ctx := context.Background()
+	server, c := newTestClient(t, DefaultToken)
+
+	fabric, err := c.Fabrics.Create(ctx, &client.Fabric{Name: stringPointer("fabric1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !server.UpdateObject("/api/v1/fabrics/fabric1", map[string]interface{}{"description": "changed outside of the client"}) {
+		t.Fatal("expected the fabric to be updated")
+	}
+
+	_, err = c.Fabrics.Update(client.WithRevision(ctx, fabric.Metadata.RevisionId), fabric.FabricId, &client.Fabric{Name: stringPointer("fabric1")})
+	if !client.IsRevisionMismatch(err) {
+		t.Errorf("expected a revision mismatch, got %v", err)
+	}
+	updated, err := c.Fabrics.Update(client.WithRevision(ctx, "2"), fabric.FabricId, &client.Fabric{Name: stringPointer("fabric1")})
+	if err != nil || updated.Metadata.RevisionId != "3" {
+		t.Errorf("expected the update of the current revision, got %+v, err: %v", updated, err)
+	}
+}
+
+func TestCandidateChanges(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t, DefaultToken)
+
+	fabric, err := c.Fabrics.Create(ctx, &client.Fabric{Name: stringPointer("fabric1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	vni, err := c.Vnis.Create(ctx, fabric.FabricId, &client.Vni{Name: stringPointer("vni1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Vnis.Update(ctx, fabric.FabricId, vni.Id, &client.Vni{Name: stringPointer("vni1"), Description: stringPointer("updated")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	vrf, err := c.Vrfs.Create(ctx, fabric.FabricId, &client.Vrf{Name: stringPointer("vrf1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Vrfs.Delete(ctx, fabric.FabricId, vrf.Id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	candidate, err := c.Candidates.Get(ctx, "fabric1", client.DefaultCandidate)
+	if err != nil || candidate == nil {
+		t.Fatalf("expected the candidate configuration, got %+v, err: %v", candidate, err)
+	}
+	if len(candidate.Changes) != 1 {
+		t.Fatalf("expected the creation of the VNI only, got %+v", candidate.Changes)
+	}
+	change := candidate.Changes[0]
+	if change.ObjectType != "VNI" || change.ObjectId != vni.Id || change.Operation != "CREATE" || !contains(change.Fields, "name") || !contains(change.Fields, "description") {
+		t.Errorf("expected the creation of the VNI with its name and description, got %+v", change)
+	}
+	if !strings.Contains(string(candidate.Raw), `"changes"`) {
+		t.Errorf("expected the raw candidate configuration, got %s", candidate.Raw)
+	}
+
+	if err := c.Candidates.Commit(ctx, fabric.FabricId, client.DefaultCandidate, "commit"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	committed, err := c.Candidates.Get(ctx, fabric.FabricId, client.DefaultCandidate)
+	if err != nil || committed == nil || len(committed.Changes) != 0 {
+		t.Errorf("expected no pending change after the commit, got %+v, err: %v", committed, err)
+	}
+	unknown, err := c.Candidates.Get(ctx, "unknown-fabric", client.DefaultCandidate)
+	if err != nil || unknown != nil {
+		t.Errorf("expected no candidate configuration for an unknown fabric, got %+v, err: %v", unknown, err)
+	}
+}
+
+func TestOmitDefaults(t *testing.T) {
+	body := map[string]interface{}{"ports": []object{{"name": "Ethernet1_1", "enabled": false, "preventForwarding": true, "description": ""}}}
+	expected := []interface{}{map[string]interface{}{"name": "Ethernet1_1", "preventForwarding": true, "description": ""}}
+	if got := omitDefaults(body).(map[string]interface{})["ports"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the attributes set to false to be omitted, got %+v", got)
+	}
+}
@@ -9,6 +9,7 @@ package provider
 import (
 	"context"
 
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -117,43 +118,34 @@ func getAnnotationsDataSourceSchemaAttribute() schema.SetNestedAttribute {
 	}
 }
 
-func NewAnnotationResourceModel(data map[string]interface{}) AnnotationResourceModel {
+func NewAnnotationResourceModel(data client.Annotation) AnnotationResourceModel {
 	annotation := getEmptyAnnotationResourceModel()
-	for attributeName, attributeValue := range data {
-		if attributeName == "dataType" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				annotation.DataType = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "name" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				annotation.Name = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "value" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				annotation.Value = basetypes.NewStringValue(stringAttr)
-			}
-		}
+	if data.DataType != "" {
+		annotation.DataType = basetypes.NewStringValue(data.DataType)
+	}
+	if data.Name != "" {
+		annotation.Name = basetypes.NewStringValue(data.Name)
+	}
+	if data.Value != "" {
+		annotation.Value = basetypes.NewStringValue(data.Value)
 	}
 	return annotation
 }
 
-func NewAnnotationsSet(ctx context.Context, data []interface{}) basetypes.SetValue {
+func NewAnnotationsSet(ctx context.Context, data []client.Annotation) basetypes.SetValue {
 	annotations := make([]AnnotationResourceModel, 0)
 	for _, annotation := range data {
-		newAnnotation := NewAnnotationResourceModel(annotation.(map[string]interface{}))
+		newAnnotation := NewAnnotationResourceModel(annotation)
 		annotations = append(annotations, newAnnotation)
 	}
 	annotationsSet, _ := types.SetValueFrom(ctx, AnnotationResourceModelAttributeType(), annotations)
 	return annotationsSet
 }
 
-func NewNodeAnnotationsSet(ctx context.Context, data []interface{}) basetypes.SetValue {
+func NewNodeAnnotationsSet(ctx context.Context, data []client.Annotation) basetypes.SetValue {
 	annotations := make([]AnnotationResourceModel, 0)
 	for _, annotation := range data {
-		newAnnotation := NewAnnotationResourceModel(annotation.(map[string]interface{}))
+		newAnnotation := NewAnnotationResourceModel(annotation)
 		if newAnnotation.Name.ValueString() != "position" {
 			annotations = append(annotations, newAnnotation)
 		}
@@ -162,16 +154,23 @@ func NewNodeAnnotationsSet(ctx context.Context, data []interface{}) basetypes.Se
 	return annotationsSet
 }
 
-func getAnnotationsJsonPayload(ctx context.Context, data basetypes.SetValue) []map[string]string {
+func getAnnotationsJsonPayload(ctx context.Context, data basetypes.SetValue) []client.Annotation {
 	annotations := []AnnotationResourceModel{}
 	data.ElementsAs(ctx, &annotations, false)
-	annotationPayloads := []map[string]string{}
+	annotationPayloads := []client.Annotation{}
 	for _, annotation := range annotations {
-		annotationPayloads = append(annotationPayloads, map[string]string{
-			"dataType": StripQuotes(annotation.DataType.String()),
-			"name":     StripQuotes(annotation.Name.String()),
-			"value":    StripQuotes(annotation.Value.String()),
+		annotationPayloads = append(annotationPayloads, client.Annotation{
+			DataType: StripQuotes(annotation.DataType.String()),
+			Name:     StripQuotes(annotation.Name.String()),
+			Value:    StripQuotes(annotation.Value.String()),
 		})
 	}
 	return annotationPayloads
 }
+
+func getAnnotationsPayload(ctx context.Context, data basetypes.SetValue) []client.Annotation {
+	if data.IsNull() || data.IsUnknown() {
+		return nil
+	}
+	return getAnnotationsJsonPayload(ctx, data)
+}
@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -109,6 +109,8 @@ func (d *BearerTokenDataSource) Configure(ctx context.Context, req datasource.Co
 
 func (d *BearerTokenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_bearer_token")
+	ctx, span := startSpan(ctx, "data.hyperfabric_bearer_token.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *BearerTokenResourceModel
 
 	// Read Terraform configuration data into the model
@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -239,6 +237,8 @@ func (r *BearerTokenResource) Configure(ctx context.Context, req resource.Config
 
 func (r *BearerTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_bearer_token")
+	ctx, span := startSpan(ctx, "hyperfabric_bearer_token.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *BearerTokenResourceModel
 
@@ -250,28 +250,17 @@ func (r *BearerTokenResource) Create(ctx context.Context, req resource.CreateReq
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_bearer_token with name '%s'", data.Name.ValueString()))
 
-	jsonPayload := getBearerTokenJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, "/api/v1/bearerTokens", "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	bearerTokensContainer, err := container.ArrayElement(0, "tokens")
+	bearerToken, err := r.client.BearerTokens.Create(ctx, getBearerTokenPayload(data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
 
-	bearertokenId := StripQuotes(bearerTokensContainer.Search("tokenId").String())
-	if bearertokenId != "" {
-		data.Id = basetypes.NewStringValue(bearertokenId)
-		data.TokenId = basetypes.NewStringValue(bearertokenId)
-		token := StripQuotes(container.Search("token").String())
-		if token != "" {
-			data.Token = basetypes.NewStringValue(token)
+	if bearerToken != nil && bearerToken.TokenId != "" {
+		data.Id = basetypes.NewStringValue(bearerToken.TokenId)
+		data.TokenId = basetypes.NewStringValue(bearerToken.TokenId)
+		if bearerToken.Token != nil && *bearerToken.Token != "" {
+			data.Token = basetypes.NewStringValue(*bearerToken.Token)
 		}
 		getAndSetBearerTokenAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
@@ -285,6 +274,8 @@ func (r *BearerTokenResource) Create(ctx context.Context, req resource.CreateReq
 
 func (r *BearerTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_bearer_token")
+	ctx, span := startSpan(ctx, "hyperfabric_bearer_token.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *BearerTokenResourceModel
 
 	// Read Terraform prior state data into the model
@@ -311,6 +302,8 @@ func (r *BearerTokenResource) Read(ctx context.Context, req resource.ReadRequest
 
 func (r *BearerTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_bearer_token")
+	ctx, span := startSpan(ctx, "hyperfabric_bearer_token.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *BearerTokenResourceModel
 	var stateData *BearerTokenResourceModel
 
@@ -345,6 +338,8 @@ func (r *BearerTokenResource) Update(ctx context.Context, req resource.UpdateReq
 
 func (r *BearerTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_bearer_token")
+	ctx, span := startSpan(ctx, "hyperfabric_bearer_token.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *BearerTokenResourceModel
 
 	// Read Terraform prior state data into the model
@@ -355,8 +350,9 @@ func (r *BearerTokenResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_bearer_token with id '%s'", data.Id.ValueString()))
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/bearerTokens/%s", data.Id.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	err := r.client.BearerTokens.Delete(ctx, data.Id.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_bearer_token with id '%s'", data.Id.ValueString()))
@@ -372,111 +368,60 @@ func (r *BearerTokenResource) ImportState(ctx context.Context, req resource.Impo
 }
 
 func getAndSetBearerTokenAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *BearerTokenResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/bearerTokens/%s", data.Id.ValueString()), "GET", nil)
-	if diags.HasError() {
+	bearerToken, err := client.BearerTokens.Get(ctx, data.Id.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newBearerToken := *getNewBearerTokenResourceModelFromData(data)
-	// newBearerToken.Id = data.Id
-	// newBearerToken.Name = data.Name
-	// newBearerToken.Token = data.Token
-	// newBearerToken.TokenId = data.TokenId
-
-	if requestData.Data() != nil {
-		attributes := requestData.Data().(map[string]interface{})
-		for attributeName, attributeValue := range attributes {
-			if attributeName == "tokenId" && (data.Id.IsNull() || data.Id.IsUnknown() || data.Id.ValueString() == "" || data.Id.ValueString() != attributeValue.(string)) {
-				newBearerToken.Id = basetypes.NewStringValue(attributeValue.(string))
-				newBearerToken.TokenId = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "name" {
-				newBearerToken.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newBearerToken.Description = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "notAfter" {
-				timeValue, err := timetypes.NewRFC3339Value(attributeValue.(string))
-				if err == nil {
-					newBearerToken.NotAfter = timeValue
-				}
-			} else if attributeName == "notBefore" {
-				timeValue, err := timetypes.NewRFC3339Value(attributeValue.(string))
-				if err == nil {
-					newBearerToken.NotBefore = timeValue
-				}
-			} else if attributeName == "scope" {
-				newBearerToken.Scope = basetypes.NewStringValue(strings.Split(attributeValue.(string), "TOKEN_SCOPE_")[1])
-			} else if attributeName == "token" {
-				newBearerToken.Token = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "metadata" {
-				newBearerToken.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-				// } else if attributeName == "labels" {
-				// 	newBearerToken.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-				// } else if attributeName == "annotations" {
-				// 	newBearerToken.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
+
+	if bearerToken != nil {
+		if bearerToken.TokenId != "" && bearerToken.TokenId != data.Id.ValueString() {
+			newBearerToken.Id = basetypes.NewStringValue(bearerToken.TokenId)
+			newBearerToken.TokenId = basetypes.NewStringValue(bearerToken.TokenId)
+		}
+		setStringValue(&newBearerToken.Name, bearerToken.Name)
+		setStringValue(&newBearerToken.Description, bearerToken.Description)
+		if bearerToken.NotAfter != nil {
+			timeValue, err := timetypes.NewRFC3339Value(*bearerToken.NotAfter)
+			if err == nil {
+				newBearerToken.NotAfter = timeValue
 			}
 		}
+		if bearerToken.NotBefore != nil {
+			timeValue, err := timetypes.NewRFC3339Value(*bearerToken.NotBefore)
+			if err == nil {
+				newBearerToken.NotBefore = timeValue
+			}
+		}
+		if bearerToken.Scope != nil {
+			newBearerToken.Scope = basetypes.NewStringValue(strings.TrimPrefix(*bearerToken.Scope, "TOKEN_SCOPE_"))
+		}
+		setStringValue(&newBearerToken.Token, bearerToken.Token)
+		setMetadataValue(ctx, &newBearerToken.Metadata, bearerToken.Metadata)
 	} else {
 		newBearerToken.Id = basetypes.NewStringNull()
 	}
 	*data = newBearerToken
 }
 
-func getBearerTokenJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *BearerTokenResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
+func getBearerTokenPayload(data *BearerTokenResourceModel) *client.BearerToken {
+	bearerToken := &client.BearerToken{
+		Name:        getStringPayload(data.Name),
+		Description: getStringPayload(data.Description),
 	}
 
 	if !data.NotAfter.IsNull() && !data.NotAfter.IsUnknown() {
-		payloadMap["notAfter"] = data.NotAfter.ValueString()
+		bearerToken.NotAfter = client.String(data.NotAfter.ValueString())
 	}
 
 	if !data.NotBefore.IsNull() && !data.NotBefore.IsUnknown() {
-		payloadMap["notBefore"] = data.NotBefore.ValueString()
+		bearerToken.NotBefore = client.String(data.NotBefore.ValueString())
 	}
 
 	if !data.Scope.IsNull() && !data.Scope.IsUnknown() {
-		payloadMap["scope"] = fmt.Sprintf("TOKEN_SCOPE_%s", data.Scope.ValueString())
-	}
-
-	// if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-	// 	payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	// }
-
-	// if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-	// 	payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	// }
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"tokens": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+		bearerToken.Scope = client.String(fmt.Sprintf("TOKEN_SCOPE_%s", data.Scope.ValueString()))
 	}
-	return jsonPayload
+	return bearerToken
 }
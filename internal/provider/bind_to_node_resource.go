@@ -11,7 +11,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -136,6 +136,8 @@ func (r *BindToNodeResource) Configure(ctx context.Context, req resource.Configu
 
 func (r *BindToNodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_bind_to_node")
+	ctx, span := startSpan(ctx, "hyperfabric_bind_to_node.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *BindToNodeResourceModel
 
@@ -147,10 +149,13 @@ func (r *BindToNodeResource) Create(ctx context.Context, req resource.CreateRequ
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_bind_to_node with NodeId '%s' and DeviceId '%s'", data.NodeId.ValueString(), data.DeviceId.ValueString()))
 
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/devices/%s", data.NodeId.ValueString(), data.DeviceId.ValueString()), "PUT", nil)
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	err := r.client.Nodes.BindDevice(ctx, fabricId, nodeId, data.DeviceId.ValueString())
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.NodeId.ValueString())
 
 	data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/devices/%s", data.NodeId.ValueString(), data.DeviceId.ValueString()))
 	getAndSetBindToNodeAttributes(ctx, &resp.Diagnostics, r.client, data)
@@ -162,6 +167,8 @@ func (r *BindToNodeResource) Create(ctx context.Context, req resource.CreateRequ
 
 func (r *BindToNodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_bind_to_node")
+	ctx, span := startSpan(ctx, "hyperfabric_bind_to_node.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *BindToNodeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -188,6 +195,8 @@ func (r *BindToNodeResource) Read(ctx context.Context, req resource.ReadRequest,
 
 func (r *BindToNodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_bind_to_node")
+	ctx, span := startSpan(ctx, "hyperfabric_bind_to_node.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *BindToNodeResourceModel
 	var stateData *BindToNodeResourceModel
 
@@ -208,6 +217,8 @@ func (r *BindToNodeResource) Update(ctx context.Context, req resource.UpdateRequ
 
 func (r *BindToNodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_bind_to_node")
+	ctx, span := startSpan(ctx, "hyperfabric_bind_to_node.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *BindToNodeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -218,10 +229,13 @@ func (r *BindToNodeResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_bind_to_node with id '%s'", data.Id.ValueString()))
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/devices", data.NodeId.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	err := r.client.Nodes.UnbindDevice(ctx, fabricId, nodeId)
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_bind_to_node with id '%s'", data.Id.ValueString()))
 }
 
@@ -18,11 +18,13 @@ import (
 func TestAccBindToNodeResource(t *testing.T) {
 	fabricName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 	deviceId := getStringAttribute(basetypes.NewStringNull(), "TF_ACC_HYPERFABRIC_DEVICE_ID", "")
-	if deviceId == "" {
-		t.Fatalf("ERROR: Missing deviceId for test. Please configure environment variable TF_ACC_HYPERFABRIC_DEVICE_ID with a valid deviceId.")
-	}
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if deviceId == "" {
+				t.Fatalf("ERROR: Missing deviceId for test. Please configure environment variable TF_ACC_HYPERFABRIC_DEVICE_ID with a valid deviceId.")
+			}
+		},
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create with minimum config and verify provided and default Hyperfabric values.
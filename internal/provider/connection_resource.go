@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -195,47 +193,33 @@ func getLocalRemoteConnectionSchemaAttribute() schema.SingleNestedAttribute {
 	}
 }
 
-func NewLocalRemoteConnectionResourceModel(data map[string]interface{}) LocalRemoteConnectionResourceModel {
+func NewLocalRemoteConnectionResourceModel(data *client.ConnectionEndpoint) LocalRemoteConnectionResourceModel {
 	localRemoteConnection := getEmptyLocalRemoteConnectionResourceModel()
-	for attributeName, attributeValue := range data {
-		if attributeName == "nodeId" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				localRemoteConnection.NodeId = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "nodeName" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				localRemoteConnection.NodeName = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "portName" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				localRemoteConnection.PortName = basetypes.NewStringValue(stringAttr)
-			}
-		}
+	if data.NodeId != "" {
+		localRemoteConnection.NodeId = basetypes.NewStringValue(data.NodeId)
+	}
+	if data.NodeName != "" {
+		localRemoteConnection.NodeName = basetypes.NewStringValue(data.NodeName)
+	}
+	if data.PortName != "" {
+		localRemoteConnection.PortName = basetypes.NewStringValue(data.PortName)
 	}
 	return localRemoteConnection
 }
 
-func NewLocalRemoteConnectionObject(ctx context.Context, data map[string]interface{}) basetypes.ObjectValue {
+func NewLocalRemoteConnectionObject(ctx context.Context, data *client.ConnectionEndpoint) basetypes.ObjectValue {
 	localRemoteConnection := NewLocalRemoteConnectionResourceModel(data)
 	localRemoteConnectionObject, _ := types.ObjectValueFrom(ctx, LocalRemoteConnectionResourceModelAttributeType(), localRemoteConnection)
 	return localRemoteConnectionObject
 }
 
-func getLocalRemoteConnectionJsonPayload(ctx context.Context, data basetypes.ObjectValue) map[string]string {
+func getLocalRemoteConnectionJsonPayload(ctx context.Context, data basetypes.ObjectValue) *client.ConnectionEndpoint {
 	localRemoteConnection := LocalRemoteConnectionResourceModel{}
 	data.As(ctx, &localRemoteConnection, basetypes.ObjectAsOptions{})
-	localRemoteConnectionPayload := map[string]string{
-		"nodeId":   StripQuotes(localRemoteConnection.NodeId.String()),
-		"portName": StripQuotes(localRemoteConnection.PortName.String()),
+	return &client.ConnectionEndpoint{
+		NodeId:   StripQuotes(localRemoteConnection.NodeId.String()),
+		PortName: StripQuotes(localRemoteConnection.PortName.String()),
 	}
-	return localRemoteConnectionPayload
-}
-
-type ConnectionIdentifier struct {
-	Id types.String
 }
 
 func (r *ConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -362,6 +346,8 @@ func (r *ConnectionResource) Configure(ctx context.Context, req resource.Configu
 
 func (r *ConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_connection")
+	ctx, span := startSpan(ctx, "hyperfabric_connection.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *ConnectionResourceModel
 
@@ -374,24 +360,16 @@ func (r *ConnectionResource) Create(ctx context.Context, req resource.CreateRequ
 	remote := data.Remote.Attributes()
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_connection in fabric '%s' with local node '%s' interface '%s' and remote node '%s' interface '%s'", data.FabricId.ValueString(), local["node_id"].String(), local["port_name"].String(), remote["node_id"].String(), remote["port_name"].String()))
 
-	jsonPayload := getConnectionJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/connections", data.FabricId.ValueString()), "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	connectionContainer, err := container.ArrayElement(0, "connections")
+	connection, err := r.client.Connections.Create(ctx, data.FabricId.ValueString(), getConnectionPayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
-	connectionId := StripQuotes(connectionContainer.Search("id").String())
-	if connectionId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/connections/%s", data.FabricId.ValueString(), connectionId))
-		data.ConnectionId = basetypes.NewStringValue(connectionId)
+	addChangedFabric(ctx, r.client, data.FabricId.ValueString())
+
+	if connection != nil && connection.Id != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/connections/%s", data.FabricId.ValueString(), connection.Id))
+		data.ConnectionId = basetypes.NewStringValue(connection.Id)
 		getAndSetConnectionAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -404,6 +382,8 @@ func (r *ConnectionResource) Create(ctx context.Context, req resource.CreateRequ
 
 func (r *ConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_connection")
+	ctx, span := startSpan(ctx, "hyperfabric_connection.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *ConnectionResourceModel
 
 	// Read Terraform prior state data into the model
@@ -429,6 +409,8 @@ func (r *ConnectionResource) Read(ctx context.Context, req resource.ReadRequest,
 
 func (r *ConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_connection")
+	ctx, span := startSpan(ctx, "hyperfabric_connection.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *ConnectionResourceModel
 	var stateData *ConnectionResourceModel
 
@@ -442,17 +424,12 @@ func (r *ConnectionResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_connection with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getConnectionJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/connections/%s", data.FabricId.ValueString(), data.ConnectionId.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	_, err := r.client.Connections.Update(ctx, data.FabricId.ValueString(), data.ConnectionId.ValueString(), getConnectionPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 
 	getAndSetConnectionAttributes(ctx, &resp.Diagnostics, r.client, data)
 
@@ -463,6 +440,8 @@ func (r *ConnectionResource) Update(ctx context.Context, req resource.UpdateRequ
 
 func (r *ConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_connection")
+	ctx, span := startSpan(ctx, "hyperfabric_connection.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *ConnectionResourceModel
 
 	// Read Terraform prior state data into the model
@@ -474,10 +453,12 @@ func (r *ConnectionResource) Delete(ctx context.Context, req resource.DeleteRequ
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_connection with id '%s'", data.Id.ValueString()))
 	checkAndSetConnectionIds(data)
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/connections/%s", data.FabricId.ValueString(), data.ConnectionId.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	err := r.client.Connections.Delete(ctx, data.FabricId.ValueString(), data.ConnectionId.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_connection with id '%s'", data.Id.ValueString()))
 }
 
@@ -491,114 +472,53 @@ func (r *ConnectionResource) ImportState(ctx context.Context, req resource.Impor
 }
 
 func getAndSetConnectionAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *ConnectionResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/connections/%s", data.FabricId.ValueString(), data.ConnectionId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	connection, err := client.Connections.Get(ctx, data.FabricId.ValueString(), data.ConnectionId.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newConnection := *getNewConnectionResourceModelFromData(data)
 
-	if requestData.Data() != nil {
-		attributes := requestData.Data().(map[string]interface{})
-		for attributeName, attributeValue := range attributes {
-			if attributeName == "id" && (data.ConnectionId.IsNull() || data.ConnectionId.IsUnknown() || data.ConnectionId.ValueString() == "" || data.ConnectionId.ValueString() != attributeValue.(string)) {
-				newConnection.ConnectionId = basetypes.NewStringValue(attributeValue.(string))
-				newConnection.Id = basetypes.NewStringValue(fmt.Sprintf("%s/connections/%s", newConnection.FabricId.ValueString(), newConnection.ConnectionId.ValueString()))
-			} else if attributeName == "fabricId" && (data.FabricId.IsNull() || data.FabricId.IsUnknown() || data.FabricId.ValueString() == "" || data.FabricId.ValueString() != attributeValue.(string)) {
-				newConnection.FabricId = basetypes.NewStringValue(attributeValue.(string))
-				newConnection.Id = basetypes.NewStringValue(fmt.Sprintf("%s/connections/%s", newConnection.FabricId.ValueString(), newConnection.ConnectionId.ValueString()))
-			} else if attributeName == "description" {
-				newConnection.Description = basetypes.NewStringValue(attributeValue.(string))
-				// } else if attributeName == "cableType" {
-				// 	newConnection.CableType = basetypes.NewStringValue(attributeValue.(string))
-				// } else if attributeName == "cableLength" {
-				// 	newConnection.CableLength = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "pluggable" {
-				newConnection.Pluggable = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "local" {
-				newConnection.Local = NewLocalRemoteConnectionObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "remote" {
-				newConnection.Remote = NewLocalRemoteConnectionObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "osType" {
-				newConnection.OsType = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "unrecognized" {
-				newConnection.Unrecognized = basetypes.NewBoolValue(attributeValue.(bool))
-				// } else if attributeName == "metadata" {
-				// 	newConnection.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-				// } else if attributeName == "labels" {
-				// 	newConnection.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-				// } else if attributeName == "annotations" {
-				// 	newConnection.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+	if connection != nil {
+		if connection.Id != "" && connection.Id != data.ConnectionId.ValueString() {
+			newConnection.ConnectionId = basetypes.NewStringValue(connection.Id)
+			newConnection.Id = basetypes.NewStringValue(fmt.Sprintf("%s/connections/%s", newConnection.FabricId.ValueString(), newConnection.ConnectionId.ValueString()))
+		}
+		if connection.FabricId != "" && connection.FabricId != data.FabricId.ValueString() {
+			newConnection.FabricId = basetypes.NewStringValue(connection.FabricId)
+			newConnection.Id = basetypes.NewStringValue(fmt.Sprintf("%s/connections/%s", newConnection.FabricId.ValueString(), newConnection.ConnectionId.ValueString()))
 		}
+		setStringValue(&newConnection.Description, connection.Description)
+		setStringValue(&newConnection.Pluggable, connection.Pluggable)
+		if connection.Local != nil {
+			newConnection.Local = NewLocalRemoteConnectionObject(ctx, connection.Local)
+		}
+		if connection.Remote != nil {
+			newConnection.Remote = NewLocalRemoteConnectionObject(ctx, connection.Remote)
+		}
+		setStringValue(&newConnection.OsType, connection.OsType)
+		setBoolValue(&newConnection.Unrecognized, connection.Unrecognized)
 	} else {
-		data.Id = basetypes.NewStringNull()
+		newConnection.Id = basetypes.NewStringNull()
 	}
 	*data = newConnection
 }
 
-func getConnectionJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *ConnectionResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	// if !data.CableType.IsNull() && !data.CableType.IsUnknown() {
-	// 	payloadMap["cableType"] = data.CableType.ValueString()
-	// }
-
-	// if !data.CableLength.IsNull() && !data.CableLength.IsUnknown() {
-	// 	payloadMap["cableLength"] = data.CableLength.ValueFloat64()
-	// }
-
-	if !data.Pluggable.IsNull() && !data.Pluggable.IsUnknown() {
-		payloadMap["pluggable"] = data.Pluggable.ValueString()
+func getConnectionPayload(ctx context.Context, data *ConnectionResourceModel) *client.Connection {
+	connection := &client.Connection{
+		Description: getStringPayload(data.Description),
+		Pluggable:   getStringPayload(data.Pluggable),
 	}
 
 	if !data.Local.IsNull() && !data.Local.IsUnknown() {
-		payloadMap["local"] = getLocalRemoteConnectionJsonPayload(ctx, data.Local)
+		connection.Local = getLocalRemoteConnectionJsonPayload(ctx, data.Local)
 	}
 
 	if !data.Remote.IsNull() && !data.Remote.IsUnknown() {
-		payloadMap["remote"] = getLocalRemoteConnectionJsonPayload(ctx, data.Remote)
-	}
-
-	// if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-	// 	payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	// }
-
-	// if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-	// 	payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	// }
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"connections": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+		connection.Remote = getLocalRemoteConnectionJsonPayload(ctx, data.Remote)
 	}
-	return jsonPayload
+	return connection
 }
 
 func checkAndSetConnectionIds(data *ConnectionResourceModel) {
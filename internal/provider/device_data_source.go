@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -143,6 +143,8 @@ func (d *DeviceDataSource) Configure(ctx context.Context, req datasource.Configu
 
 func (d *DeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_device")
+	ctx, span := startSpan(ctx, "data.hyperfabric_device.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *DeviceDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -172,44 +174,28 @@ func (d *DeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 }
 
 func getAndSetDeviceAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *DeviceDataSourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, "/api/v1/devices", "GET", nil)
-	if diags.HasError() {
-		return
-	}
-
-	if requestData.Data() != nil {
-		responseMap := requestData.Data().(map[string]interface{})
-		for responseKey, responseValue := range responseMap {
-			if responseKey == "devices" {
-				devices := responseValue.([]interface{})
-				for _, device := range devices {
-					newDevice := *getEmptyDeviceDataSourceModel()
-					for attributeName, attributeValue := range device.(map[string]interface{}) {
-						if attributeName == "deviceId" {
-							newDevice.Id = basetypes.NewStringValue(attributeValue.(string))
-							newDevice.DeviceId = basetypes.NewStringValue(attributeValue.(string))
-						} else if attributeName == "fabricId" {
-							newDevice.FabricId = basetypes.NewStringValue(attributeValue.(string))
-						} else if attributeName == "modelName" {
-							newDevice.ModelName = basetypes.NewStringValue(attributeValue.(string))
-						} else if attributeName == "nodeId" {
-							newDevice.NodeId = basetypes.NewStringValue(attributeValue.(string))
-						} else if attributeName == "osType" {
-							newDevice.OsType = basetypes.NewStringValue(attributeValue.(string))
-						} else if attributeName == "rackId" {
-							newDevice.RackId = basetypes.NewStringValue(attributeValue.(string))
-						} else if attributeName == "roles" {
-							newDevice.Roles = NewSetString(ctx, attributeValue.([]interface{}))
-						} else if attributeName == "serialNumber" {
-							newDevice.SerialNumber = basetypes.NewStringValue(attributeValue.(string))
-						}
-					}
-					if (!data.SerialNumber.IsNull() && !data.SerialNumber.IsUnknown() && data.SerialNumber.ValueString() != "" && newDevice.SerialNumber == data.SerialNumber) ||
-						(!data.DeviceId.IsNull() && !data.DeviceId.IsUnknown() && data.DeviceId.ValueString() != "" && newDevice.DeviceId == data.DeviceId) {
-						*data = newDevice
-					}
-				}
-			}
+	devicesIterator := client.Devices.Iter(nil)
+	for devicesIterator.Next(ctx) {
+		device := devicesIterator.Value()
+		newDevice := *getEmptyDeviceDataSourceModel()
+		if device.DeviceId != "" {
+			newDevice.Id = basetypes.NewStringValue(device.DeviceId)
+			newDevice.DeviceId = basetypes.NewStringValue(device.DeviceId)
 		}
+		setStringValue(&newDevice.FabricId, device.FabricId)
+		setStringValue(&newDevice.ModelName, device.ModelName)
+		setStringValue(&newDevice.NodeId, device.NodeId)
+		setStringValue(&newDevice.OsType, device.OsType)
+		setStringValue(&newDevice.RackId, device.RackId)
+		setSetStringValue(ctx, &newDevice.Roles, device.Roles)
+		setStringValue(&newDevice.SerialNumber, device.SerialNumber)
+		if (!data.SerialNumber.IsNull() && !data.SerialNumber.IsUnknown() && data.SerialNumber.ValueString() != "" && newDevice.SerialNumber == data.SerialNumber) ||
+			(!data.DeviceId.IsNull() && !data.DeviceId.IsUnknown() && data.DeviceId.ValueString() != "" && newDevice.DeviceId == data.DeviceId) {
+			*data = newDevice
+			return
+		}
+	}
+	if err := devicesIterator.Err(); err != nil {
+		AddClientError(diags, err)
 	}
 }
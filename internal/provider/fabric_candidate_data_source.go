@@ -0,0 +1,206 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FabricCandidateDataSource{}
+
+func NewFabricCandidateDataSource() datasource.DataSource {
+	return &FabricCandidateDataSource{}
+}
+
+// FabricCandidateDataSource defines the data source implementation.
+type FabricCandidateDataSource struct {
+	client *client.Client
+}
+
+// FabricCandidateDataSourceModel describes the data source data model.
+type FabricCandidateDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	FabricId  types.String `tfsdk:"fabric_id"`
+	Candidate types.String `tfsdk:"candidate"`
+	Changes   types.List   `tfsdk:"changes"`
+	RawJson   types.String `tfsdk:"raw_json"`
+}
+
+// FabricCandidateChangeDataSourceModel describes a pending change of the candidate configuration of a Fabric.
+type FabricCandidateChangeDataSourceModel struct {
+	ObjectType types.String `tfsdk:"object_type"`
+	ObjectId   types.String `tfsdk:"object_id"`
+	Operation  types.String `tfsdk:"operation"`
+	Fields     types.List   `tfsdk:"fields"`
+}
+
+func FabricCandidateChangeDataSourceModelAttributeType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"object_type": types.StringType,
+			"object_id":   types.StringType,
+			"operation":   types.StringType,
+			"fields":      types.ListType{ElemType: types.StringType},
+		},
+	}
+}
+
+func (r *FabricCandidateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	tflog.Debug(ctx, "Start metadata of datasource: hyperfabric_fabric_candidate")
+	resp.TypeName = req.ProviderTypeName + "_fabric_candidate"
+	tflog.Debug(ctx, "End metadata of datasource: hyperfabric_fabric_candidate")
+}
+
+func (r *FabricCandidateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	tflog.Debug(ctx, "Start schema of datasource: hyperfabric_fabric_candidate")
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fabric Candidate data source, lists the changes of a Fabric pending in a candidate configuration until they are committed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`id` defines the unique identifier of the candidate configuration of the Fabric.",
+			},
+			"fabric_id": schema.StringAttribute{
+				MarkdownDescription: "`fabric_id` defines the unique identifier of a Fabric.",
+				Required:            true,
+			},
+			"candidate": schema.StringAttribute{
+				MarkdownDescription: "`candidate` defines the name of the candidate configuration. Defaults to the candidate configuration of the provider.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"changes": schema.ListNestedAttribute{
+				MarkdownDescription: "The pending changes of the candidate configuration.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"object_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the changed object, such as `node`, `port` or `VNI`.",
+							Computed:            true,
+						},
+						"object_id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the changed object.",
+							Computed:            true,
+						},
+						"operation": schema.StringAttribute{
+							MarkdownDescription: "The operation of the change: `CREATE`, `UPDATE` or `DELETE`.",
+							Computed:            true,
+						},
+						"fields": schema.ListAttribute{
+							MarkdownDescription: "The names of the changed attributes of the object.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"raw_json": schema.StringAttribute{
+				MarkdownDescription: "The candidate configuration as returned by the Hyperfabric service, in JSON.",
+				Computed:            true,
+			},
+		},
+	}
+	tflog.Debug(ctx, "End schema of datasource: hyperfabric_fabric_candidate")
+}
+
+func (r *FabricCandidateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Debug(ctx, "Start configure of datasource: hyperfabric_fabric_candidate")
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	tflog.Debug(ctx, "End configure of datasource: hyperfabric_fabric_candidate")
+}
+
+func (r *FabricCandidateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Start read of datasource: hyperfabric_fabric_candidate")
+	ctx, span := startSpan(ctx, "data.hyperfabric_fabric_candidate.Read")
+	defer endSpan(span, &resp.Diagnostics)
+	var data *FabricCandidateDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Candidate.IsNull() || data.Candidate.IsUnknown() {
+		data.Candidate = basetypes.NewStringValue(r.client.GetCandidate())
+	}
+	data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/candidates/%s", data.FabricId.ValueString(), data.Candidate.ValueString()))
+
+	tflog.Debug(ctx, fmt.Sprintf("Read of datasource hyperfabric_fabric_candidate with id '%s'", data.Id.ValueString()))
+
+	candidate, err := r.client.Candidates.Get(ctx, data.FabricId.ValueString(), data.Candidate.ValueString())
+	if errors.Is(err, client.ErrCandidateAPIDisabled) {
+		resp.Diagnostics.AddError(
+			"The hyperfabric_fabric_candidate data source is disabled",
+			"The hyperfabric_fabric_candidate data source reads an endpoint which is not documented by the Hyperfabric API. Set experimental_candidate_api in the provider configuration to enable it.",
+		)
+		return
+	}
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
+		return
+	}
+	if candidate == nil {
+		resp.Diagnostics.AddError(
+			"Failed to read hyperfabric_fabric_candidate data source",
+			fmt.Sprintf("The hyperfabric_fabric_candidate data source with id '%s' has not been found", data.Id.ValueString()),
+		)
+		return
+	}
+
+	changes := make([]FabricCandidateChangeDataSourceModel, 0, len(candidate.Changes))
+	for _, change := range candidate.Changes {
+		fields, diags := types.ListValueFrom(ctx, types.StringType, change.Fields)
+		resp.Diagnostics.Append(diags...)
+		changes = append(changes, FabricCandidateChangeDataSourceModel{
+			ObjectType: basetypes.NewStringValue(change.ObjectType),
+			ObjectId:   basetypes.NewStringValue(change.ObjectId),
+			Operation:  basetypes.NewStringValue(change.Operation),
+			Fields:     fields,
+		})
+	}
+	changesList, diags := types.ListValueFrom(ctx, FabricCandidateChangeDataSourceModelAttributeType(), changes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Changes = changesList
+	data.RawJson = basetypes.NewStringValue(string(candidate.Raw))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	tflog.Debug(ctx, fmt.Sprintf("End read of datasource hyperfabric_fabric_candidate with id '%s'", data.Id.ValueString()))
+}
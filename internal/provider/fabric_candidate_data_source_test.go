@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFabricCandidateDataSource(t *testing.T) {
+	fabricName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify that the data source is disabled unless experimental_candidate_api is set.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Candidate - Verify that the data source is disabled unless experimental_candidate_api is set.")
+				},
+				Config: `
+data "hyperfabric_fabric_candidate" "test" {
+  fabric_id = "unknown-fabric"
+}
+`,
+				ExpectError: regexp.MustCompile("experimental_candidate_api"),
+			},
+			// Verify that the error of the candidate configuration of an unknown fabric is returned.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Candidate - Verify that the error of the candidate configuration of an unknown fabric is returned.")
+				},
+				Config: testFabricCandidateProviderHclConfig + `
+data "hyperfabric_fabric_candidate" "test" {
+  fabric_id = "unknown-fabric"
+}
+`,
+				ExpectError: regexp.MustCompile("has not been found"),
+			},
+			// List the pending creation of a VNI.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Candidate - List the pending creation of a VNI.")
+				},
+				Config:             testFabricCandidateDataSourceHclConfig(fabricName, false),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hyperfabric_fabric_candidate.test", "candidate", "default"),
+					resource.TestCheckResourceAttrPair("data.hyperfabric_fabric_candidate.test", "fabric_id", "hyperfabric_fabric.test", "id"),
+					resource.TestCheckResourceAttrSet("data.hyperfabric_fabric_candidate.test", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.hyperfabric_fabric_candidate.test", "changes.*", map[string]string{
+						"object_type": "VNI",
+						"operation":   "CREATE",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("data.hyperfabric_fabric_candidate.test", "changes.*.object_id", "hyperfabric_vni.test", "vni_id"),
+					resource.TestCheckTypeSetElemAttr("data.hyperfabric_fabric_candidate.test", "changes.*.fields.*", "description"),
+					resource.TestMatchResourceAttr("data.hyperfabric_fabric_candidate.test", "raw_json", regexp.MustCompile(`"changes"`)),
+				),
+			},
+			// List no pending change once the candidate configuration is committed.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Candidate - List no pending change once the candidate configuration is committed.")
+				},
+				Config:             testFabricCandidateDataSourceHclConfig(fabricName, true),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hyperfabric_fabric_candidate.test", "changes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testFabricCandidateProviderHclConfig enables the hyperfabric_fabric_candidate data source.
+const testFabricCandidateProviderHclConfig = `
+provider "hyperfabric" {
+  experimental_candidate_api = true
+}
+`
+
+func testFabricCandidateDataSourceHclConfig(fabricName string, commit bool) string {
+	dependsOn := "hyperfabric_vni.test"
+	commitConfig := ""
+	if commit {
+		dependsOn = "hyperfabric_fabric_commit.test"
+		commitConfig = `
+resource "hyperfabric_fabric_commit" "test" {
+  fabric_id = hyperfabric_fabric.test.id
+  triggers = {
+    vni = hyperfabric_vni.test.id
+  }
+}
+`
+	}
+	return testFabricCandidateProviderHclConfig + fmt.Sprintf(`
+resource "hyperfabric_fabric" "test" {
+  name = "%[1]s"
+}
+
+resource "hyperfabric_vni" "test" {
+  fabric_id   = hyperfabric_fabric.test.id
+  name        = "vni1"
+  description = "Pending VNI"
+}
+%[2]s
+data "hyperfabric_fabric_candidate" "test" {
+  fabric_id  = hyperfabric_fabric.test.id
+  depends_on = [%[3]s]
+}
+`, fabricName, commitConfig, dependsOn)
+}
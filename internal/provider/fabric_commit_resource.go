@@ -0,0 +1,231 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FabricCommitResource{}
+
+// defaultCommitComments are the comments of the commits of the hyperfabric_fabric_commit resource without comments.
+const defaultCommitComments = "Terraform Commit"
+
+func NewFabricCommitResource() resource.Resource {
+	return &FabricCommitResource{}
+}
+
+// FabricCommitResource defines the resource implementation.
+type FabricCommitResource struct {
+	client *client.Client
+}
+
+// FabricCommitResourceModel describes the resource data model.
+type FabricCommitResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	FabricId  types.String `tfsdk:"fabric_id"`
+	Candidate types.String `tfsdk:"candidate"`
+	Comments  types.String `tfsdk:"comments"`
+	Triggers  types.Map    `tfsdk:"triggers"`
+}
+
+func (r *FabricCommitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_fabric_commit")
+	resp.TypeName = req.ProviderTypeName + "_fabric_commit"
+	tflog.Debug(ctx, "End metadata of resource: hyperfabric_fabric_commit")
+}
+
+func (r *FabricCommitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	tflog.Debug(ctx, "Start schema of resource: hyperfabric_fabric_commit")
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fabric Commit resource, commits the candidate configuration of a Fabric to its running configuration when created or replaced.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`id` defines the unique identifier of the committed candidate configuration of the Fabric.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fabric_id": schema.StringAttribute{
+				MarkdownDescription: "`fabric_id` defines the unique identifier of a Fabric.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"candidate": schema.StringAttribute{
+				MarkdownDescription: "`candidate` defines the name of the candidate configuration to commit. Defaults to the candidate configuration of the provider.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("`comments` describes the changes of the commit. Defaults to `%s`.", defaultCommitComments),
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultCommitComments),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "`triggers` defines arbitrary values which commit the candidate configuration again when changed, such as the ids or attributes of the resources of the changes to commit.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+	tflog.Debug(ctx, "End schema of resource: hyperfabric_fabric_commit")
+}
+
+func (r *FabricCommitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Debug(ctx, "Start configure of resource: hyperfabric_fabric_commit")
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	tflog.Debug(ctx, "End configure of resource: hyperfabric_fabric_commit")
+}
+
+func (r *FabricCommitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Start create of resource: hyperfabric_fabric_commit")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric_commit.Create")
+	defer endSpan(span, &resp.Diagnostics)
+
+	var data *FabricCommitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Candidate.IsNull() || data.Candidate.IsUnknown() {
+		data.Candidate = basetypes.NewStringValue(r.client.GetCandidate())
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_fabric_commit with fabric_id '%s' and candidate '%s'", data.FabricId.ValueString(), data.Candidate.ValueString()))
+
+	err := r.client.Candidates.Commit(ctx, data.FabricId.ValueString(), data.Candidate.ValueString(), data.Comments.ValueString())
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
+		return
+	}
+	data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/candidates/%s", data.FabricId.ValueString(), data.Candidate.ValueString()))
+
+	// The committed changes must not be committed again by the auto-commit.
+	r.client.CommittedCandidate(data.FabricId.ValueString(), data.Candidate.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	tflog.Debug(ctx, fmt.Sprintf("End create of resource hyperfabric_fabric_commit with id '%s'", data.Id.ValueString()))
+}
+
+func (r *FabricCommitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Start read of resource: hyperfabric_fabric_commit")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric_commit.Read")
+	defer endSpan(span, &resp.Diagnostics)
+	var data *FabricCommitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read of resource hyperfabric_fabric_commit with id '%s'", data.Id.ValueString()))
+
+	// A commit cannot be read back, it is kept as long as its fabric exists
+	fabric, err := r.client.Fabrics.Get(ctx, data.FabricId.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	// Save updated data into Terraform state
+	if fabric == nil {
+		var emptyData *FabricCommitResourceModel
+		resp.Diagnostics.Append(resp.State.Set(ctx, &emptyData)...)
+	} else {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("End read of resource hyperfabric_fabric_commit with id '%s'", data.Id.ValueString()))
+}
+
+func (r *FabricCommitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Start update of resource: hyperfabric_fabric_commit")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric_commit.Update")
+	defer endSpan(span, &resp.Diagnostics)
+	var data *FabricCommitResourceModel
+
+	// Read Terraform plan data into the model, every attribute requires a replacement so there is nothing to commit
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_fabric_commit with id '%s'", data.Id.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	tflog.Debug(ctx, fmt.Sprintf("End update of resource hyperfabric_fabric_commit with id '%s'", data.Id.ValueString()))
+}
+
+func (r *FabricCommitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Start delete of resource: hyperfabric_fabric_commit")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric_commit.Delete")
+	defer endSpan(span, &resp.Diagnostics)
+	var data *FabricCommitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A commit cannot be undone, deleting the resource only removes it from the state
+	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_fabric_commit with id '%s'", data.Id.ValueString()))
+	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_fabric_commit with id '%s'", data.Id.ValueString()))
+}
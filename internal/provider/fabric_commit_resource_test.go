@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccFabricCommitResource(t *testing.T) {
+	fabricName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify that the error of the commit of an unknown fabric is returned.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Commit - Verify that the error of the commit of an unknown fabric is returned.")
+				},
+				Config: `
+resource "hyperfabric_fabric_commit" "test" {
+  fabric_id = "unknown-fabric"
+}
+`,
+				ExpectError: regexp.MustCompile("404"),
+			},
+			// Commit the candidate configuration of a fabric after the creation of a VNI.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Commit - Commit the candidate configuration of a fabric after the creation of a VNI.")
+				},
+				Config:             testFabricCommitResourceHclConfig(fabricName, "vni1", ""),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hyperfabric_fabric_commit.test", "candidate", "default"),
+					resource.TestCheckResourceAttr("hyperfabric_fabric_commit.test", "comments", "Terraform Commit"),
+					resource.TestCheckResourceAttrPair("hyperfabric_fabric_commit.test", "triggers.vni", "hyperfabric_vni.test", "id"),
+					resource.TestCheckResourceAttrSet("hyperfabric_fabric_commit.test", "id"),
+					testCheckFabricCommits(fabricName, "Terraform Commit"),
+				),
+			},
+			// Commit again when the triggers change.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Commit - Commit again when the triggers change.")
+				},
+				Config:             testFabricCommitResourceHclConfig(fabricName, "vni2", "Rename the VNI"),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hyperfabric_fabric_commit.test", "comments", "Rename the VNI"),
+					testCheckFabricCommits(fabricName, "Terraform Commit", "Rename the VNI"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFabricCommitResourceAutoCommit(t *testing.T) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		t.Skip("Acceptance test of the commits sent with auto_commit requires TF_ACC_HYPERFABRIC_FAKE_API to be set")
+	}
+	fabricName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify that the changes committed by the resource are not committed again by the auto-commit.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Fabric Commit - Verify that the changes committed by the resource are not committed again by the auto-commit.")
+				},
+				Config: testFabricCommitResourceAutoCommitHclConfig(fabricName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(s *terraform.State) error {
+						DoAutoCommit()
+						return nil
+					},
+					testCheckFabricCommits(fabricName, "Terraform Commit"),
+				),
+			},
+		},
+	})
+}
+
+// testCheckFabricCommits checks the comments of the commits received by the fake Hyperfabric API for the fabric.
+func testCheckFabricCommits(fabricName string, comments ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccFakeAPI == nil {
+			return nil
+		}
+		commits := testAccFakeAPI.Commits(fabricName)
+		if len(commits) != len(comments) {
+			return fmt.Errorf("expected %d commits of fabric %s, got %+v", len(comments), fabricName, commits)
+		}
+		for i, commit := range commits {
+			if commit.Comments != comments[i] {
+				return fmt.Errorf("expected the comments %q for the commit %d of fabric %s, got %q", comments[i], i, fabricName, commit.Comments)
+			}
+		}
+		return nil
+	}
+}
+
+func testFabricCommitResourceHclConfig(fabricName, vniName, comments string) string {
+	commentsConfigLine := ""
+	if comments != "" {
+		commentsConfigLine = fmt.Sprintf("comments = %q", comments)
+	}
+	return fmt.Sprintf(`
+resource "hyperfabric_fabric" "test" {
+  name = "%[1]s"
+}
+
+resource "hyperfabric_vni" "test" {
+  fabric_id = hyperfabric_fabric.test.id
+  name      = "%[2]s"
+}
+
+resource "hyperfabric_fabric_commit" "test" {
+  fabric_id = hyperfabric_fabric.test.id
+  %[3]s
+  triggers = {
+    vni = hyperfabric_vni.test.id
+    name = hyperfabric_vni.test.name
+  }
+}
+`, fabricName, vniName, commentsConfigLine)
+}
+
+func testFabricCommitResourceAutoCommitHclConfig(fabricName string) string {
+	return fmt.Sprintf(`
+provider "hyperfabric" {
+  auto_commit = true
+}
+
+%s`, testFabricCommitResourceHclConfig(fabricName, "vni1", ""))
+}
@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -109,6 +109,8 @@ func (d *FabricDataSource) Configure(ctx context.Context, req datasource.Configu
 
 func (d *FabricDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_fabric")
+	ctx, span := startSpan(ctx, "data.hyperfabric_fabric.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *FabricResourceModel
 
 	// Read Terraform configuration data into the model
@@ -8,11 +8,9 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -29,6 +27,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FabricResource{}
 var _ resource.ResourceWithImportState = &FabricResource{}
+var _ resource.ResourceWithModifyPlan = &FabricResource{}
 
 func NewFabricResource() resource.Resource {
 	return &FabricResource{}
@@ -125,6 +124,10 @@ type FabricIdentifier struct {
 	Id types.String
 }
 
+func (r *FabricResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *FabricResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_fabric")
 	resp.TypeName = req.ProviderTypeName + "_fabric"
@@ -246,6 +249,8 @@ func (r *FabricResource) Configure(ctx context.Context, req resource.ConfigureRe
 
 func (r *FabricResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_fabric")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *FabricResourceModel
 
@@ -257,24 +262,14 @@ func (r *FabricResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_fabric with name '%s'", data.Name.ValueString()))
 
-	jsonPayload := getFabricJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, "/api/v1/fabrics", "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	fabricContainer, err := container.ArrayElement(0, "fabrics")
+	fabric, err := r.client.Fabrics.Create(ctx, getFabricPayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
 
-	fabricId := StripQuotes(fabricContainer.Search("fabricId").String())
-	if fabricId != "" {
-		data.Id = basetypes.NewStringValue(fabricId)
+	if fabric != nil && fabric.FabricId != "" {
+		data.Id = basetypes.NewStringValue(fabric.FabricId)
 		getAndSetFabricAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -287,6 +282,8 @@ func (r *FabricResource) Create(ctx context.Context, req resource.CreateRequest,
 
 func (r *FabricResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_fabric")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *FabricResourceModel
 
 	// Read Terraform prior state data into the model
@@ -313,6 +310,8 @@ func (r *FabricResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 func (r *FabricResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_fabric")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *FabricResourceModel
 	var stateData *FabricResourceModel
 
@@ -326,15 +325,9 @@ func (r *FabricResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_fabric with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getFabricJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s", data.Id.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	_, err := r.client.Fabrics.Update(withRevision(ctx, stateData.Metadata), data.Id.ValueString(), getFabricPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
 
@@ -347,6 +340,8 @@ func (r *FabricResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 func (r *FabricResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_fabric")
+	ctx, span := startSpan(ctx, "hyperfabric_fabric.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *FabricResourceModel
 
 	// Read Terraform prior state data into the model
@@ -357,8 +352,9 @@ func (r *FabricResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_fabric with id '%s'", data.Id.ValueString()))
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s", data.Id.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	err := r.client.Fabrics.Delete(withRevision(ctx, data.Metadata), data.Id.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_fabric with id '%s'", data.Id.ValueString()))
@@ -374,41 +370,29 @@ func (r *FabricResource) ImportState(ctx context.Context, req resource.ImportSta
 }
 
 func getAndSetFabricAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *FabricResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s", data.Id.ValueString()), "GET", nil)
-	if diags.HasError() {
+	fabric, err := client.Fabrics.Get(ctx, data.Id.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newFabric := *getNewFabricResourceModelFromData(data)
 
-	if requestData.Data() != nil {
-		attributes := requestData.Data().(map[string]interface{})
-		for attributeName, attributeValue := range attributes {
-			if attributeName == "fabricId" && (data.Id.IsNull() || data.Id.IsUnknown() || data.Id.ValueString() == "" || data.Id.ValueString() != attributeValue.(string)) {
-				newFabric.Id = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "name" {
-				newFabric.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newFabric.Description = basetypes.NewStringValue(attributeValue.(string))
-				// } else if attributeName == "enabled" {
-				// 	newFabric.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "topology" {
-				newFabric.Topology = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "location" {
-				newFabric.Location = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "address" {
-				newFabric.Address = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "city" {
-				newFabric.City = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "country" {
-				newFabric.Country = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "metadata" {
-				newFabric.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newFabric.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "annotations" {
-				newFabric.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+	if fabric != nil {
+		if fabric.FabricId != "" {
+			newFabric.Id = basetypes.NewStringValue(fabric.FabricId)
+		}
+		setStringValue(&newFabric.Name, fabric.Name)
+		setStringValue(&newFabric.Description, fabric.Description)
+		setStringValue(&newFabric.Topology, fabric.Topology)
+		setStringValue(&newFabric.Location, fabric.Location)
+		setStringValue(&newFabric.Address, fabric.Address)
+		setStringValue(&newFabric.City, fabric.City)
+		setStringValue(&newFabric.Country, fabric.Country)
+		setMetadataValue(ctx, &newFabric.Metadata, fabric.Metadata)
+		setSetStringValue(ctx, &newFabric.Labels, fabric.Labels)
+		if fabric.Annotations != nil {
+			newFabric.Annotations = NewAnnotationsSet(ctx, fabric.Annotations)
 		}
 	} else {
 		newFabric.Id = basetypes.NewStringNull()
@@ -416,74 +400,16 @@ func getAndSetFabricAttributes(ctx context.Context, diags *diag.Diagnostics, cli
 	*data = newFabric
 }
 
-func getFabricJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *FabricResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	// if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-	// 	payloadMap["enabled"] = data.Enabled.ValueBool()
-	// }
-
-	if !data.Topology.IsNull() && !data.Topology.IsUnknown() {
-		payloadMap["topology"] = data.Topology.ValueString()
-	}
-
-	if !data.Location.IsNull() && !data.Location.IsUnknown() {
-		payloadMap["location"] = data.Location.ValueString()
-	}
-
-	if !data.Address.IsNull() && !data.Address.IsUnknown() {
-		payloadMap["address"] = data.Address.ValueString()
-	}
-
-	if !data.City.IsNull() && !data.City.IsUnknown() {
-		payloadMap["city"] = data.City.ValueString()
-	}
-
-	if !data.Country.IsNull() && !data.Country.IsUnknown() {
-		payloadMap["country"] = data.Country.ValueString()
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	}
-
-	if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-		payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	}
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"fabrics": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+func getFabricPayload(ctx context.Context, data *FabricResourceModel) *client.Fabric {
+	return &client.Fabric{
+		Name:        getStringPayload(data.Name),
+		Description: getStringPayload(data.Description),
+		Topology:    getStringPayload(data.Topology),
+		Location:    getStringPayload(data.Location),
+		Address:     getStringPayload(data.Address),
+		City:        getStringPayload(data.City),
+		Country:     getStringPayload(data.Country),
+		Labels:      getSetStringPayload(ctx, data.Labels),
+		Annotations: getAnnotationsPayload(ctx, data.Annotations),
 	}
-	return jsonPayload
 }
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
@@ -182,69 +183,57 @@ func getMembersDataSourceSchemaAttribute() schema.SetNestedAttribute {
 	}
 }
 
-func NewMemberResourceModel(ctx context.Context, data *MemberResourceModel, attributes map[string]interface{}) MemberResourceModel {
+func NewMemberResourceModel(ctx context.Context, data *MemberResourceModel, attributes client.Member) MemberResourceModel {
 	tflog.Debug(ctx, fmt.Sprintf("MEMBER LHLOG '%v' & '%v'", data, attributes))
 	member := *getNewMemberResourceModelFromData(data)
-	isUntagged := false
-	for attributeName, attributeValue := range attributes {
-		if attributeName == "port" && attributeValue != nil {
-			for portAttributeName, portAttributeValue := range attributeValue.(map[string]interface{}) {
-				if portAttributeName == "portName" {
-					member.PortName = basetypes.NewStringValue(portAttributeValue.(string))
-				} else if portAttributeName == "nodeId" {
-					member.NodeId = basetypes.NewStringValue(portAttributeValue.(string))
-				} else if portAttributeName == "nodeName" {
-					member.NodeName = basetypes.NewStringValue(portAttributeValue.(string))
-				}
-			}
-		} else if attributeName == "vlanId" {
-			member.VlanId = basetypes.NewFloat64Value(attributeValue.(float64))
-		} else if attributeName == "untagged" {
-			isUntagged = attributeValue.(bool)
-		}
+	if attributes.Port != nil {
+		setStringValue(&member.PortName, attributes.Port.PortName)
+		setStringValue(&member.NodeId, attributes.Port.NodeId)
+		setStringValue(&member.NodeName, attributes.Port.NodeName)
 	}
-	if isUntagged {
+	setFloat64Value(&member.VlanId, attributes.VlanId)
+	if attributes.Untagged {
 		member.VlanId = basetypes.NewFloat64Null()
 	}
 	tflog.Debug(ctx, fmt.Sprintf("MEMBER LHLOG2 '%v' & '%v' & '%v'", data, attributes, member))
 	return member
 }
 
-func NewMembersSet(ctx context.Context, data *[]MemberResourceModel, requestData []interface{}) basetypes.SetValue {
+func NewMembersSet(ctx context.Context, data *[]MemberResourceModel, requestData []client.Member) basetypes.SetValue {
 	members := make([]MemberResourceModel, 0)
 	for _, member := range requestData {
-		newMember := NewMemberResourceModel(ctx, getEmptyMemberResourceModel(), member.(map[string]interface{}))
+		newMember := NewMemberResourceModel(ctx, getEmptyMemberResourceModel(), member)
 		members = append(members, newMember)
 	}
 	membersSet, _ := types.SetValueFrom(ctx, MemberResourceModelAttributeType(), members)
 	return membersSet
 }
 
-func NewMembersSetFromSetValue(ctx context.Context, data *[]MemberResourceModel, requestData []interface{}) basetypes.SetValue {
+func NewMembersSetFromSetValue(ctx context.Context, data *[]MemberResourceModel, requestData []client.Member) basetypes.SetValue {
 	members := make([]MemberResourceModel, 0)
 	for index, member := range requestData {
-		newMember := NewMemberResourceModel(ctx, &(*data)[index], member.(map[string]interface{}))
+		newMember := NewMemberResourceModel(ctx, &(*data)[index], member)
 		members = append(members, newMember)
 	}
 	membersSet, _ := types.SetValueFrom(ctx, MemberResourceModelAttributeType(), members)
 	return membersSet
 }
 
-func getMembersJsonPayload(ctx context.Context, data basetypes.SetValue) []map[string]interface{} {
+func getMembersJsonPayload(ctx context.Context, data basetypes.SetValue) []client.Member {
 	members := []MemberResourceModel{}
 	data.ElementsAs(ctx, &members, false)
-	memberPayloads := make([]map[string]interface{}, 0)
+	memberPayloads := make([]client.Member, 0)
 	for _, member := range members {
-		memberPayload := map[string]interface{}{
-			"port": map[string]string{
-				"portName": StripQuotes(member.PortName.String()),
-				"nodeId":   StripQuotes(member.NodeId.String()),
+		memberPayload := client.Member{
+			Port: &client.MemberPort{
+				PortName: client.String(StripQuotes(member.PortName.String())),
+				NodeId:   client.String(StripQuotes(member.NodeId.String())),
 			},
 		}
 		if !member.VlanId.IsNull() && !member.VlanId.IsUnknown() {
-			memberPayload["vlanId"] = member.VlanId.ValueFloat64()
+			memberPayload.VlanId = client.Float64(member.VlanId.ValueFloat64())
 		} else {
-			memberPayload["untagged"] = true
+			memberPayload.Untagged = true
 		}
 		memberPayloads = append(memberPayloads, memberPayload)
 	}
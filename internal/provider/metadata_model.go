@@ -9,13 +9,17 @@ package provider
 import (
 	"context"
 
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 type MetadataResourceModel struct {
@@ -99,41 +103,62 @@ func getMetadataSchemaAttribute() schema.SingleNestedAttribute {
 	}
 }
 
-func NewMetadataResourceModel(data map[string]interface{}) MetadataResourceModel {
-	metadata := getEmptyMetadataResourceModel()
-	for attributeName, attributeValue := range data {
-		if attributeName == "createdAt" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				metadata.CreatedAt = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "createdBy" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				metadata.CreatedBy = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "modifiedAt" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				metadata.ModifiedAt = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "modifiedBy" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				metadata.ModifiedBy = basetypes.NewStringValue(stringAttr)
-			}
-		} else if attributeName == "revisionId" && attributeValue != nil {
-			stringAttr := attributeValue.(string)
-			if stringAttr != "" {
-				metadata.RevisionId = basetypes.NewStringValue(stringAttr)
-			}
+// setMetadataUnknownWhenUpdated makes the modification metadata and the revision of the plan of a resource unknown
+// when the resource is updated, since the update changes them. It is called from the ModifyPlan of the resources.
+func setMetadataUnknownWhenUpdated(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var metadata types.Object
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("metadata"), &metadata)...)
+	if resp.Diagnostics.HasError() || metadata.IsNull() || metadata.IsUnknown() {
+		return
+	}
+	diffs, err := resp.Plan.Raw.Diff(req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Comparison of the plan and the state failed", err.Error())
+		return
+	}
+	for _, diff := range diffs {
+		if steps := diff.Path.Steps(); len(steps) > 0 && steps[0].Equal(tftypes.AttributeName("metadata")) {
+			continue
 		}
+		for _, attributeName := range []string{"modified_at", "modified_by", "revision_id"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("metadata").AtName(attributeName), basetypes.NewStringUnknown())...)
+		}
+		return
+	}
+}
+
+func NewMetadataResourceModel(data *client.Metadata) MetadataResourceModel {
+	metadata := getEmptyMetadataResourceModel()
+	if data.CreatedAt != "" {
+		metadata.CreatedAt = basetypes.NewStringValue(data.CreatedAt)
+	}
+	if data.CreatedBy != "" {
+		metadata.CreatedBy = basetypes.NewStringValue(data.CreatedBy)
+	}
+	if data.ModifiedAt != "" {
+		metadata.ModifiedAt = basetypes.NewStringValue(data.ModifiedAt)
+	}
+	if data.ModifiedBy != "" {
+		metadata.ModifiedBy = basetypes.NewStringValue(data.ModifiedBy)
+	}
+	if data.RevisionId != "" {
+		metadata.RevisionId = basetypes.NewStringValue(data.RevisionId)
 	}
 	return metadata
 }
 
-func NewMetadataObject(ctx context.Context, data map[string]interface{}) basetypes.ObjectValue {
+func NewMetadataObject(ctx context.Context, data *client.Metadata) basetypes.ObjectValue {
 	metadata := NewMetadataResourceModel(data)
 	metadataObject, _ := types.ObjectValueFrom(ctx, MetadataResourceModelAttributeType(), metadata)
 	return metadataObject
 }
+
+// setMetadataValue sets attribute from the metadata of a response, when present.
+func setMetadataValue(ctx context.Context, attribute *basetypes.ObjectValue, data *client.Metadata) {
+	if data != nil {
+		*attribute = NewMetadataObject(ctx, data)
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -118,6 +118,8 @@ func (d *NodeDataSource) Configure(ctx context.Context, req datasource.Configure
 
 func (d *NodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_node")
+	ctx, span := startSpan(ctx, "data.hyperfabric_node.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeResourceModel
 
 	// Read Terraform configuration data into the model
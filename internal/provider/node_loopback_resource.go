@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NodeLoopbackResource{}
 var _ resource.ResourceWithImportState = &NodeLoopbackResource{}
+var _ resource.ResourceWithModifyPlan = &NodeLoopbackResource{}
 
 func NewNodeLoopbackResource() resource.Resource {
 	return &NodeLoopbackResource{}
@@ -129,6 +128,10 @@ type NodeLoopbackIdentifier struct {
 	Id types.String
 }
 
+func (r *NodeLoopbackResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *NodeLoopbackResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_node_loopback")
 	resp.TypeName = req.ProviderTypeName + "_node_loopback"
@@ -247,6 +250,8 @@ func (r *NodeLoopbackResource) Configure(ctx context.Context, req resource.Confi
 
 func (r *NodeLoopbackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_node_loopback")
+	ctx, span := startSpan(ctx, "hyperfabric_node_loopback.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *NodeLoopbackResourceModel
 
@@ -258,25 +263,17 @@ func (r *NodeLoopbackResource) Create(ctx context.Context, req resource.CreateRe
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_node_loopback with name '%s'", data.Name.ValueString()))
 
-	jsonPayload := getNodeLoopbackJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/loopbacks", data.NodeId.ValueString()), "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	loopbackContainer, err := container.ArrayElement(0, "loopbacks")
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	loopback, err := r.client.Loopbacks.Create(ctx, fabricId, nodeId, getNodeLoopbackPayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.NodeId.ValueString())
 
-	loopbackId := StripQuotes(loopbackContainer.Search("id").String())
-	if loopbackId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", data.NodeId.ValueString(), loopbackId))
-		data.LoopbackId = basetypes.NewStringValue(loopbackId)
+	if loopback != nil && loopback.Id != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", data.NodeId.ValueString(), loopback.Id))
+		data.LoopbackId = basetypes.NewStringValue(loopback.Id)
 		getAndSetNodeLoopbackAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -289,6 +286,8 @@ func (r *NodeLoopbackResource) Create(ctx context.Context, req resource.CreateRe
 
 func (r *NodeLoopbackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_node_loopback")
+	ctx, span := startSpan(ctx, "hyperfabric_node_loopback.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeLoopbackResourceModel
 
 	// Read Terraform prior state data into the model
@@ -314,6 +313,8 @@ func (r *NodeLoopbackResource) Read(ctx context.Context, req resource.ReadReques
 
 func (r *NodeLoopbackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_node_loopback")
+	ctx, span := startSpan(ctx, "hyperfabric_node_loopback.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeLoopbackResourceModel
 	var stateData *NodeLoopbackResourceModel
 
@@ -327,17 +328,13 @@ func (r *NodeLoopbackResource) Update(ctx context.Context, req resource.UpdateRe
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_node_loopback with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getNodeLoopbackJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/loopbacks/%s", data.NodeId.ValueString(), data.LoopbackId.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	_, err := r.client.Loopbacks.Update(withRevision(ctx, stateData.Metadata), fabricId, nodeId, data.LoopbackId.ValueString(), getNodeLoopbackPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 
 	getAndSetNodeLoopbackAttributes(ctx, &resp.Diagnostics, r.client, data)
 
@@ -348,6 +345,8 @@ func (r *NodeLoopbackResource) Update(ctx context.Context, req resource.UpdateRe
 
 func (r *NodeLoopbackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_node_loopback")
+	ctx, span := startSpan(ctx, "hyperfabric_node_loopback.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeLoopbackResourceModel
 
 	// Read Terraform prior state data into the model
@@ -359,10 +358,13 @@ func (r *NodeLoopbackResource) Delete(ctx context.Context, req resource.DeleteRe
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_node_loopback with id '%s'", data.Id.ValueString()))
 	checkAndSetNodeLoopbackIds(data)
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/loopbacks/%s", data.NodeId.ValueString(), data.LoopbackId.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	err := r.client.Loopbacks.Delete(withRevision(ctx, data.Metadata), fabricId, nodeId, data.LoopbackId.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_node_loopback with id '%s'", data.Id.ValueString()))
 }
 
@@ -376,8 +378,10 @@ func (r *NodeLoopbackResource) ImportState(ctx context.Context, req resource.Imp
 }
 
 func getAndSetNodeLoopbackAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *NodeLoopbackResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/loopbacks/%s", data.NodeId.ValueString(), data.LoopbackId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	loopback, err := client.Loopbacks.Get(ctx, fabricId, nodeId, data.LoopbackId.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
@@ -386,36 +390,30 @@ func getAndSetNodeLoopbackAttributes(ctx context.Context, diags *diag.Diagnostic
 	node.Id = newNodeLoopback.NodeId
 	checkAndSetNodeIds(node)
 
-	if requestData.Data() != nil {
-		for attributeName, attributeValue := range requestData.Data().(map[string]interface{}) {
-			if attributeName == "id" && (data.LoopbackId.IsNull() || data.LoopbackId.IsUnknown() || data.LoopbackId.ValueString() == "" || data.LoopbackId.ValueString() != attributeValue.(string)) {
-				newNodeLoopback.LoopbackId = basetypes.NewStringValue(attributeValue.(string))
-				newNodeLoopback.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodeLoopback.NodeId.ValueString(), newNodeLoopback.LoopbackId.ValueString()))
-			} else if attributeName == "fabricId" && (node.FabricId.IsNull() || node.FabricId.IsUnknown() || node.FabricId.ValueString() == "" || node.FabricId.ValueString() != attributeValue.(string)) {
-				node.FabricId = basetypes.NewStringValue(attributeValue.(string))
-				newNodeLoopback.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
-				newNodeLoopback.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodeLoopback.NodeId.ValueString(), newNodeLoopback.LoopbackId.ValueString()))
-			} else if attributeName == "nodeId" && (node.NodeId.IsNull() || node.NodeId.IsUnknown() || node.NodeId.ValueString() == "" || node.NodeId.ValueString() != attributeValue.(string)) {
-				node.NodeId = basetypes.NewStringValue(attributeValue.(string))
-				newNodeLoopback.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
-				newNodeLoopback.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodeLoopback.NodeId.ValueString(), newNodeLoopback.LoopbackId.ValueString()))
-			} else if attributeName == "name" {
-				newNodeLoopback.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newNodeLoopback.Description = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "ipv4Address" {
-				newNodeLoopback.Ipv4Address = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "ipv6Address" {
-				newNodeLoopback.Ipv6Address = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "vrfId" {
-				newNodeLoopback.VrfId = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "metadata" {
-				newNodeLoopback.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newNodeLoopback.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "annotations" {
-				newNodeLoopback.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+	if loopback != nil {
+		if loopback.Id != "" && loopback.Id != data.LoopbackId.ValueString() {
+			newNodeLoopback.LoopbackId = basetypes.NewStringValue(loopback.Id)
+			newNodeLoopback.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodeLoopback.NodeId.ValueString(), newNodeLoopback.LoopbackId.ValueString()))
+		}
+		if loopback.FabricId != "" && loopback.FabricId != node.FabricId.ValueString() {
+			node.FabricId = basetypes.NewStringValue(loopback.FabricId)
+			newNodeLoopback.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
+			newNodeLoopback.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodeLoopback.NodeId.ValueString(), newNodeLoopback.LoopbackId.ValueString()))
+		}
+		if loopback.NodeId != "" && loopback.NodeId != node.NodeId.ValueString() {
+			node.NodeId = basetypes.NewStringValue(loopback.NodeId)
+			newNodeLoopback.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
+			newNodeLoopback.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodeLoopback.NodeId.ValueString(), newNodeLoopback.LoopbackId.ValueString()))
+		}
+		setStringValue(&newNodeLoopback.Name, loopback.Name)
+		setStringValue(&newNodeLoopback.Description, loopback.Description)
+		setStringValue(&newNodeLoopback.Ipv4Address, loopback.Ipv4Address)
+		setStringValue(&newNodeLoopback.Ipv6Address, loopback.Ipv6Address)
+		setStringValue(&newNodeLoopback.VrfId, loopback.VrfId)
+		setMetadataValue(ctx, &newNodeLoopback.Metadata, loopback.Metadata)
+		setSetStringValue(ctx, &newNodeLoopback.Labels, loopback.Labels)
+		if loopback.Annotations != nil {
+			newNodeLoopback.Annotations = NewAnnotationsSet(ctx, loopback.Annotations)
 		}
 	} else {
 		newNodeLoopback.Id = basetypes.NewStringNull()
@@ -423,69 +421,17 @@ func getAndSetNodeLoopbackAttributes(ctx context.Context, diags *diag.Diagnostic
 	*data = newNodeLoopback
 }
 
-func getNodeLoopbackJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *NodeLoopbackResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	// if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-	// 	payloadMap["enabled"] = data.Enabled.ValueBool()
-	// }
-	payloadMap["enabled"] = true
-
-	if !data.Ipv4Address.IsNull() && !data.Ipv4Address.IsUnknown() {
-		payloadMap["ipv4Address"] = data.Ipv4Address.ValueString()
-	}
-
-	if !data.Ipv6Address.IsNull() && !data.Ipv6Address.IsUnknown() {
-		payloadMap["ipv6Address"] = data.Ipv6Address.ValueString()
-	}
-
-	if !data.VrfId.IsNull() && !data.VrfId.IsUnknown() {
-		payloadMap["vrfId"] = data.VrfId.ValueString()
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	}
-
-	if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-		payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	}
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"loopbacks": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+func getNodeLoopbackPayload(ctx context.Context, data *NodeLoopbackResourceModel) *client.Loopback {
+	return &client.Loopback{
+		Name:        getStringPayload(data.Name),
+		Description: getStringPayload(data.Description),
+		Enabled:     client.Bool(true),
+		Ipv4Address: getStringPayload(data.Ipv4Address),
+		Ipv6Address: getStringPayload(data.Ipv6Address),
+		VrfId:       getStringPayload(data.VrfId),
+		Labels:      getSetStringPayload(ctx, data.Labels),
+		Annotations: getAnnotationsPayload(ctx, data.Annotations),
 	}
-	return jsonPayload
 }
 
 func checkAndSetNodeLoopbackIds(data *NodeLoopbackResourceModel) {
@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -150,6 +150,8 @@ func (d *NodeManagementPortDataSource) Configure(ctx context.Context, req dataso
 
 func (d *NodeManagementPortDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_node_management_port")
+	ctx, span := startSpan(ctx, "data.hyperfabric_node_management_port.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeManagementPortResourceModel
 
 	// Read Terraform configuration data into the model
@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -33,6 +31,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NodeManagementPortResource{}
 var _ resource.ResourceWithImportState = &NodeManagementPortResource{}
+var _ resource.ResourceWithModifyPlan = &NodeManagementPortResource{}
 
 func NewNodeManagementPortResource() resource.Resource {
 	return &NodeManagementPortResource{}
@@ -216,6 +215,10 @@ type NodeManagementPortIdentifier struct {
 	Id types.String
 }
 
+func (r *NodeManagementPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *NodeManagementPortResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_node_management_port")
 	resp.TypeName = req.ProviderTypeName + "_node_management_port"
@@ -553,6 +556,8 @@ func (r *NodeManagementPortResource) Configure(ctx context.Context, req resource
 
 func (r *NodeManagementPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_node_management_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_management_port.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *NodeManagementPortResourceModel
 
@@ -564,25 +569,17 @@ func (r *NodeManagementPortResource) Create(ctx context.Context, req resource.Cr
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_node_management_port with name '%s'", data.Name.ValueString()))
 
-	jsonPayload := getNodeManagementPortJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/managementPorts", data.NodeId.ValueString()), "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	managementPortContainer, err := container.ArrayElement(0, "ports")
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	managementPort, err := r.client.ManagementPorts.Create(ctx, fabricId, nodeId, getNodeManagementPortPayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.NodeId.ValueString())
 
-	managementPortId := StripQuotes(managementPortContainer.Search("id").String())
-	if managementPortId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/managementPorts/%s", data.NodeId.ValueString(), managementPortId))
-		data.NodeManagementPortId = basetypes.NewStringValue(managementPortId)
+	if managementPort != nil && managementPort.Id != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/managementPorts/%s", data.NodeId.ValueString(), managementPort.Id))
+		data.NodeManagementPortId = basetypes.NewStringValue(managementPort.Id)
 		getAndSetNodeManagementPortAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -595,6 +592,8 @@ func (r *NodeManagementPortResource) Create(ctx context.Context, req resource.Cr
 
 func (r *NodeManagementPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_node_management_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_management_port.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeManagementPortResourceModel
 
 	// Read Terraform prior state data into the model
@@ -620,6 +619,8 @@ func (r *NodeManagementPortResource) Read(ctx context.Context, req resource.Read
 
 func (r *NodeManagementPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_node_management_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_management_port.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeManagementPortResourceModel
 	var stateData *NodeManagementPortResourceModel
 
@@ -633,17 +634,13 @@ func (r *NodeManagementPortResource) Update(ctx context.Context, req resource.Up
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_node_management_port with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getNodeManagementPortJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/managementPorts/%s", data.NodeId.ValueString(), data.NodeManagementPortId.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	_, err := r.client.ManagementPorts.Update(withRevision(ctx, stateData.Metadata), fabricId, nodeId, data.NodeManagementPortId.ValueString(), getNodeManagementPortPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 
 	getAndSetNodeManagementPortAttributes(ctx, &resp.Diagnostics, r.client, data)
 
@@ -654,6 +651,8 @@ func (r *NodeManagementPortResource) Update(ctx context.Context, req resource.Up
 
 func (r *NodeManagementPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_node_management_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_management_port.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeManagementPortResourceModel
 
 	// Read Terraform prior state data into the model
@@ -694,197 +693,83 @@ func (r *NodeManagementPortResource) ImportState(ctx context.Context, req resour
 }
 
 func getAndSetNodeManagementPortAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *NodeManagementPortResourceModel) {
-	// requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/managementPorts/%s", data.NodeId.ValueString(), data.NodeManagementPortId.ValueString()), "GET", nil)
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/managementPorts", data.NodeId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	// A node has a single management port, the scan stops as soon as a second one is found.
+	managementPortsIterator := client.ManagementPorts.Iter(fabricId, nodeId, nil)
+	found := managementPortsIterator.Next(ctx)
+	managementPort := managementPortsIterator.Value()
+	multiple := found && managementPortsIterator.Next(ctx)
+	if err := managementPortsIterator.Err(); err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newNodeManagementPort := *getNewNodeManagementPortResourceModelFromData(data)
 
-	if requestData.Data() != nil {
-		requestMap := requestData.Data().(map[string]interface{})
-		for _, ports := range requestMap {
-			listPorts := ports.([]interface{})
-			if len(listPorts) == 1 {
-				for attributeName, attributeValue := range listPorts[0].(map[string]interface{}) {
-					// if attributeName == "nodeId" && (data.NodeId.IsNull() || data.NodeId.IsUnknown() || data.NodeId.ValueString() == "" || data.NodeId.ValueString() != attributeValue.(string)) {
-					// 	newNodeManagementPort.NodeId = basetypes.NewStringValue(attributeValue.(string))
-					// 	newNodeManagementPort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s/managementPorts/%s", newNodeManagementPort.FabricId.ValueString(), newNodeManagementPort.NodeId.ValueString(), newNodeManagementPort.NodeManagementPortId.ValueString()))
-					// } else
-					if attributeName == "id" && (data.NodeManagementPortId.IsNull() || data.NodeManagementPortId.IsUnknown() || data.NodeManagementPortId.ValueString() == "" || data.NodeManagementPortId.ValueString() != attributeValue.(string)) {
-						newNodeManagementPort.NodeManagementPortId = basetypes.NewStringValue(attributeValue.(string))
-						newNodeManagementPort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/managementPorts/%s", newNodeManagementPort.NodeId.ValueString(), newNodeManagementPort.NodeManagementPortId.ValueString()))
-					} else if attributeName == "name" {
-						newNodeManagementPort.Name = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "description" {
-						newNodeManagementPort.Description = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "enabled" {
-						newNodeManagementPort.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-					} else if attributeName == "cloudUrls" {
-						newNodeManagementPort.CloudUrls = NewSetString(ctx, attributeValue.([]interface{}))
-					} else if attributeName == "ipv4ConfigType" {
-						newNodeManagementPort.Ipv4ConfigType = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "ipv4Address" {
-						newNodeManagementPort.Ipv4Address = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "ipv4Gateway" {
-						newNodeManagementPort.Ipv4Gateway = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "ipv6ConfigType" {
-						newNodeManagementPort.Ipv6ConfigType = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "ipv6Address" {
-						newNodeManagementPort.Ipv6Address = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "ipv6Gateway" {
-						newNodeManagementPort.Ipv6Gateway = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "dnsAddresses" {
-						newNodeManagementPort.DnsAddresses = NewSetString(ctx, attributeValue.([]interface{}))
-					} else if attributeName == "ntpAddresses" {
-						newNodeManagementPort.NtpAddresses = NewSetString(ctx, attributeValue.([]interface{}))
-					} else if attributeName == "noProxy" {
-						newNodeManagementPort.NoProxy = NewSetString(ctx, attributeValue.([]interface{}))
-					} else if attributeName == "proxyAddress" {
-						newNodeManagementPort.ProxyAddress = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "proxyCredentialId" {
-						newNodeManagementPort.ProxyCredentialId = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "proxyUsername" {
-						newNodeManagementPort.ProxyUsername = basetypes.NewStringValue(attributeValue.(string))
-						// Not setting password as it is not returned and want to keep state intact
-						// } else if attributeName == "proxyPassword" {
-						// 	newNodeManagementPort.ProxyPassword = basetypes.NewStringValue(attributeValue.(string))
-						// } else if attributeName == "setProxyPassword" {
-						// 	newNodeManagementPort.SetProxyPassword = basetypes.NewBoolValue(attributeValue.(bool))
-					} else if attributeName == "connectedState" {
-						newNodeManagementPort.ConnectedState = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "configOrigin" {
-						newNodeManagementPort.ConfigOrigin = basetypes.NewStringValue(attributeValue.(string))
-					} else if attributeName == "metadata" {
-						newNodeManagementPort.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-						// } else if attributeName == "labels" {
-						// 	newNodeManagementPort.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-						// } else if attributeName == "annotations" {
-						// 	newNodeManagementPort.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-					}
-				}
-			} else {
-				tflog.Debug(ctx, fmt.Sprintf("Wrong number of management ports in hyperfabric_node_management_port with id '%s", data.Id.ValueString()))
-				newNodeManagementPort.Id = basetypes.NewStringNull()
-			}
+	if found && !multiple {
+		if managementPort.Id != "" && managementPort.Id != data.NodeManagementPortId.ValueString() {
+			newNodeManagementPort.NodeManagementPortId = basetypes.NewStringValue(managementPort.Id)
+			newNodeManagementPort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/managementPorts/%s", newNodeManagementPort.NodeId.ValueString(), newNodeManagementPort.NodeManagementPortId.ValueString()))
 		}
+		setStringValue(&newNodeManagementPort.Name, managementPort.Name)
+		setStringValue(&newNodeManagementPort.Description, managementPort.Description)
+		setBoolValue(&newNodeManagementPort.Enabled, managementPort.Enabled)
+		setSetStringValue(ctx, &newNodeManagementPort.CloudUrls, managementPort.CloudUrls)
+		setStringValue(&newNodeManagementPort.Ipv4ConfigType, managementPort.Ipv4ConfigType)
+		setStringValue(&newNodeManagementPort.Ipv4Address, managementPort.Ipv4Address)
+		setStringValue(&newNodeManagementPort.Ipv4Gateway, managementPort.Ipv4Gateway)
+		setStringValue(&newNodeManagementPort.Ipv6ConfigType, managementPort.Ipv6ConfigType)
+		setStringValue(&newNodeManagementPort.Ipv6Address, managementPort.Ipv6Address)
+		setStringValue(&newNodeManagementPort.Ipv6Gateway, managementPort.Ipv6Gateway)
+		setSetStringValue(ctx, &newNodeManagementPort.DnsAddresses, managementPort.DnsAddresses)
+		setSetStringValue(ctx, &newNodeManagementPort.NtpAddresses, managementPort.NtpAddresses)
+		setSetStringValue(ctx, &newNodeManagementPort.NoProxy, managementPort.NoProxy)
+		setStringValue(&newNodeManagementPort.ProxyAddress, managementPort.ProxyAddress)
+		setStringValue(&newNodeManagementPort.ProxyCredentialId, managementPort.ProxyCredentialId)
+		// Not setting password as it is not returned and want to keep state intact
+		setStringValue(&newNodeManagementPort.ProxyUsername, managementPort.ProxyUsername)
+		setStringValue(&newNodeManagementPort.ConnectedState, managementPort.ConnectedState)
+		setStringValue(&newNodeManagementPort.ConfigOrigin, managementPort.ConfigOrigin)
+		setMetadataValue(ctx, &newNodeManagementPort.Metadata, managementPort.Metadata)
 	} else {
+		if multiple {
+			tflog.Debug(ctx, fmt.Sprintf("Wrong number of management ports in hyperfabric_node_management_port with id '%s", data.Id.ValueString()))
+		}
 		newNodeManagementPort.Id = basetypes.NewStringNull()
 	}
 	*data = newNodeManagementPort
 }
 
-func getNodeManagementPortJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *NodeManagementPortResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-		payloadMap["enabled"] = data.Enabled.ValueBool()
-	}
-
-	if !data.CloudUrls.IsNull() && !data.CloudUrls.IsUnknown() {
-		payloadMap["cloud_urls"] = getSetStringJsonPayload(ctx, data.CloudUrls)
-	}
-
-	if !data.Ipv4ConfigType.IsNull() && !data.Ipv4ConfigType.IsUnknown() {
-		payloadMap["ipv4ConfigType"] = data.Ipv4ConfigType.ValueString()
-	}
-
-	if !data.Ipv4Address.IsNull() && !data.Ipv4Address.IsUnknown() {
-		payloadMap["ipv4Address"] = data.Ipv4Address.ValueString()
-	}
-
-	if !data.Ipv4Gateway.IsNull() && !data.Ipv4Gateway.IsUnknown() {
-		payloadMap["ipv4Gateway"] = data.Ipv4Gateway.ValueString()
-	}
-
-	if !data.Ipv6ConfigType.IsNull() && !data.Ipv6ConfigType.IsUnknown() {
-		payloadMap["ipv6ConfigType"] = data.Ipv6ConfigType.ValueString()
-	}
-
-	if !data.Ipv6Address.IsNull() && !data.Ipv6Address.IsUnknown() {
-		payloadMap["ipv6Address"] = data.Ipv6Address.ValueString()
-	}
-
-	if !data.Ipv6Gateway.IsNull() && !data.Ipv6Gateway.IsUnknown() {
-		payloadMap["ipv6Gateway"] = data.Ipv6Gateway.ValueString()
-	}
-
-	if !data.DnsAddresses.IsNull() && !data.DnsAddresses.IsUnknown() {
-		payloadMap["dnsAddresses"] = getSetStringJsonPayload(ctx, data.DnsAddresses)
-	}
-
-	if !data.NtpAddresses.IsNull() && !data.NtpAddresses.IsUnknown() {
-		payloadMap["ntpAddresses"] = getSetStringJsonPayload(ctx, data.NtpAddresses)
-	}
-
-	if !data.NoProxy.IsNull() && !data.NoProxy.IsUnknown() {
-		payloadMap["noProxy"] = getSetStringJsonPayload(ctx, data.NoProxy)
-	}
-
-	if !data.ProxyAddress.IsNull() && !data.ProxyAddress.IsUnknown() {
-		payloadMap["proxyAddress"] = data.ProxyAddress.ValueString()
+func getNodeManagementPortPayload(ctx context.Context, data *NodeManagementPortResourceModel) *client.ManagementPort {
+	managementPort := &client.ManagementPort{
+		Name:           getStringPayload(data.Name),
+		Description:    getStringPayload(data.Description),
+		Enabled:        getBoolPayload(data.Enabled),
+		CloudUrls:      getSetStringPayload(ctx, data.CloudUrls),
+		Ipv4ConfigType: getStringPayload(data.Ipv4ConfigType),
+		Ipv4Address:    getStringPayload(data.Ipv4Address),
+		Ipv4Gateway:    getStringPayload(data.Ipv4Gateway),
+		Ipv6ConfigType: getStringPayload(data.Ipv6ConfigType),
+		Ipv6Address:    getStringPayload(data.Ipv6Address),
+		Ipv6Gateway:    getStringPayload(data.Ipv6Gateway),
+		DnsAddresses:   getSetStringPayload(ctx, data.DnsAddresses),
+		NtpAddresses:   getSetStringPayload(ctx, data.NtpAddresses),
+		NoProxy:        getSetStringPayload(ctx, data.NoProxy),
+		ProxyAddress:   getStringPayload(data.ProxyAddress),
+		ProxyUsername:  getStringPayload(data.ProxyUsername),
 	}
 
 	if !data.ProxyPassword.IsNull() && !data.ProxyPassword.IsUnknown() {
-		payloadMap["proxyPassword"] = data.ProxyPassword.ValueString()
-		payloadMap["setProxyPassword"] = true
+		managementPort.ProxyPassword = client.String(data.ProxyPassword.ValueString())
+		managementPort.SetProxyPassword = client.Bool(true)
 	}
 
-	if !data.ProxyUsername.IsNull() && !data.ProxyUsername.IsUnknown() {
-		payloadMap["proxyUsername"] = data.ProxyUsername.ValueString()
-	}
-
-	if (data.ProxyPassword.IsNull() && data.ProxyPassword.IsUnknown() && data.ProxyUsername.IsNull() && data.ProxyUsername.IsUnknown()) ||
-		(!data.ProxyUsername.IsNull() && !data.ProxyUsername.IsUnknown() && data.ProxyUsername.ValueString() == "") {
-		payloadMap["setProxyPassword"] = false
-		delete(payloadMap, "proxyUsername")
-		delete(payloadMap, "proxyPassword")
-	}
-
-	// if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-	// 	payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	// }
-
-	// if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-	// 	payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	// }
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"ports": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+	if !data.ProxyUsername.IsNull() && !data.ProxyUsername.IsUnknown() && data.ProxyUsername.ValueString() == "" {
+		managementPort.SetProxyPassword = client.Bool(false)
+		managementPort.ProxyUsername = nil
+		managementPort.ProxyPassword = nil
 	}
-	return jsonPayload
+	return managementPort
 }
 
 func checkAndSetNodeManagementPortIds(data *NodeManagementPortResourceModel) {
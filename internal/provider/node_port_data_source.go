@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -155,6 +155,8 @@ func (d *NodePortDataSource) Configure(ctx context.Context, req datasource.Confi
 
 func (d *NodePortDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_node_port")
+	ctx, span := startSpan(ctx, "data.hyperfabric_node_port.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodePortResourceModel
 
 	// Read Terraform configuration data into the model
@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -34,6 +32,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NodePortResource{}
 var _ resource.ResourceWithImportState = &NodePortResource{}
+var _ resource.ResourceWithModifyPlan = &NodePortResource{}
 
 func NewNodePortResource() resource.Resource {
 	return &NodePortResource{}
@@ -225,6 +224,10 @@ type NodePortIdentifier struct {
 	Id types.String
 }
 
+func (r *NodePortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *NodePortResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_node_port")
 	resp.TypeName = req.ProviderTypeName + "_node_port"
@@ -505,6 +508,8 @@ func (r *NodePortResource) Configure(ctx context.Context, req resource.Configure
 
 func (r *NodePortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_node_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_port.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *NodePortResourceModel
 
@@ -516,20 +521,17 @@ func (r *NodePortResource) Create(ctx context.Context, req resource.CreateReques
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_node_port with name '%s'", data.Name.ValueString()))
 
-	jsonPayload := getNodePortJsonPayload(ctx, &resp.Diagnostics, data, "update")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/ports/%s", data.NodeId.ValueString(), data.Name.ValueString()), "PUT", jsonPayload)
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	port, err := r.client.Ports.Update(ctx, fabricId, nodeId, data.Name.ValueString(), getNodePortPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.NodeId.ValueString())
 
-	portId := StripQuotes(container.Search("id").String())
-	if portId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/ports/%s", data.NodeId.ValueString(), portId))
-		data.PortId = basetypes.NewStringValue(portId)
+	if port != nil && port.Id != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/ports/%s", data.NodeId.ValueString(), port.Id))
+		data.PortId = basetypes.NewStringValue(port.Id)
 		getAndSetNodePortAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -542,6 +544,8 @@ func (r *NodePortResource) Create(ctx context.Context, req resource.CreateReques
 
 func (r *NodePortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_node_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_port.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodePortResourceModel
 
 	// Read Terraform prior state data into the model
@@ -567,6 +571,8 @@ func (r *NodePortResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 func (r *NodePortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_node_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_port.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodePortResourceModel
 	var stateData *NodePortResourceModel
 
@@ -580,17 +586,13 @@ func (r *NodePortResource) Update(ctx context.Context, req resource.UpdateReques
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_node_port with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getNodePortJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/ports/%s", data.NodeId.ValueString(), data.Name.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	_, err := r.client.Ports.Update(withRevision(ctx, stateData.Metadata), fabricId, nodeId, data.Name.ValueString(), getNodePortPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 
 	getAndSetNodePortAttributes(ctx, &resp.Diagnostics, r.client, data)
 
@@ -601,6 +603,8 @@ func (r *NodePortResource) Update(ctx context.Context, req resource.UpdateReques
 
 func (r *NodePortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_node_port")
+	ctx, span := startSpan(ctx, "hyperfabric_node_port.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodePortResourceModel
 
 	// Read Terraform prior state data into the model
@@ -612,10 +616,13 @@ func (r *NodePortResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_node_port with id '%s'", data.Id.ValueString()))
 	checkAndSetNodePortIds(data)
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/ports/%s", data.NodeId.ValueString(), data.Name.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	err := r.client.Ports.Delete(withRevision(ctx, data.Metadata), fabricId, nodeId, data.Name.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_node_port with id '%s'", data.Id.ValueString()))
 }
 
@@ -629,8 +636,10 @@ func (r *NodePortResource) ImportState(ctx context.Context, req resource.ImportS
 }
 
 func getAndSetNodePortAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *NodePortResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/ports/%s", data.NodeId.ValueString(), data.PortId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	port, err := client.Ports.Get(ctx, fabricId, nodeId, data.PortId.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
@@ -639,68 +648,44 @@ func getAndSetNodePortAttributes(ctx context.Context, diags *diag.Diagnostics, c
 	node.Id = newNodePort.NodeId
 	checkAndSetNodeIds(node)
 
-	if requestData.Data() != nil {
-		for attributeName, attributeValue := range requestData.Data().(map[string]interface{}) {
-			if attributeName == "id" && (data.PortId.IsNull() || data.PortId.IsUnknown() || data.PortId.ValueString() == "" || data.PortId.ValueString() != attributeValue.(string)) {
-				newNodePort.PortId = basetypes.NewStringValue(attributeValue.(string))
-				newNodePort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/ports/%s", newNodePort.NodeId.ValueString(), newNodePort.PortId.ValueString()))
-			} else if attributeName == "fabricId" && (node.FabricId.IsNull() || node.FabricId.IsUnknown() || node.FabricId.ValueString() == "" || node.FabricId.ValueString() != attributeValue.(string)) {
-				node.FabricId = basetypes.NewStringValue(attributeValue.(string))
-				newNodePort.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
-				newNodePort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodePort.NodeId.ValueString(), newNodePort.PortId.ValueString()))
-			} else if attributeName == "nodeId" && (node.NodeId.IsNull() || node.NodeId.IsUnknown() || node.NodeId.ValueString() == "" || node.NodeId.ValueString() != attributeValue.(string)) {
-				node.NodeId = basetypes.NewStringValue(attributeValue.(string))
-				newNodePort.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
-				newNodePort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/loopbacks/%s", newNodePort.NodeId.ValueString(), newNodePort.PortId.ValueString()))
-			} else if attributeName == "name" {
-				newNodePort.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newNodePort.Description = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "enabled" {
-				newNodePort.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-				// } else if attributeName == "breakout" {
-				// 	newNodePort.Breakout = basetypes.NewBoolValue(attributeValue.(bool))
-				// } else if attributeName == "breakoutIndex" {
-				// 	newNodePort.BreakoutIndex = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "index" {
-				newNodePort.Index = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "ipv4Addresses" {
-				newNodePort.Ipv4Addresses = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "ipv6Addresses" {
-				newNodePort.Ipv6Addresses = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "linecard" {
-				newNodePort.Linecard = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "linkDown" {
-				newNodePort.PreventForwarding = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "lldpHost" {
-				newNodePort.LldpHost = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "lldpInfo" {
-				newNodePort.LldpInfo = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "lldpPort" {
-				newNodePort.LldpPort = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "maxSpeed" {
-				newNodePort.MaxSpeed = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "mtu" {
-				newNodePort.Mtu = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "roles" {
-				newNodePort.Roles = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "speed" {
-				newNodePort.Speed = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "subInfCount" {
-				newNodePort.SubInterfacesCount = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "vlanIds" {
-				newNodePort.VlanIds = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "vnis" {
-				newNodePort.Vnis = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "vrfId" {
-				newNodePort.VrfId = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "metadata" {
-				newNodePort.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newNodePort.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "annotations" {
-				newNodePort.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+	if port != nil {
+		if port.Id != "" && port.Id != data.PortId.ValueString() {
+			newNodePort.PortId = basetypes.NewStringValue(port.Id)
+			newNodePort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/ports/%s", newNodePort.NodeId.ValueString(), newNodePort.PortId.ValueString()))
+		}
+		if port.FabricId != "" && port.FabricId != node.FabricId.ValueString() {
+			node.FabricId = basetypes.NewStringValue(port.FabricId)
+			newNodePort.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
+			newNodePort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/ports/%s", newNodePort.NodeId.ValueString(), newNodePort.PortId.ValueString()))
+		}
+		if port.NodeId != "" && port.NodeId != node.NodeId.ValueString() {
+			node.NodeId = basetypes.NewStringValue(port.NodeId)
+			newNodePort.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
+			newNodePort.Id = basetypes.NewStringValue(fmt.Sprintf("%s/ports/%s", newNodePort.NodeId.ValueString(), newNodePort.PortId.ValueString()))
+		}
+		setStringValue(&newNodePort.Name, port.Name)
+		setStringValue(&newNodePort.Description, port.Description)
+		setBoolValue(&newNodePort.Enabled, port.Enabled)
+		setFloat64Value(&newNodePort.Index, port.Index)
+		setSetStringValue(ctx, &newNodePort.Ipv4Addresses, port.Ipv4Addresses)
+		setSetStringValue(ctx, &newNodePort.Ipv6Addresses, port.Ipv6Addresses)
+		setFloat64Value(&newNodePort.Linecard, port.Linecard)
+		setBoolValue(&newNodePort.PreventForwarding, port.LinkDown)
+		setStringValue(&newNodePort.LldpHost, port.LldpHost)
+		setStringValue(&newNodePort.LldpInfo, port.LldpInfo)
+		setStringValue(&newNodePort.LldpPort, port.LldpPort)
+		setStringValue(&newNodePort.MaxSpeed, port.MaxSpeed)
+		setFloat64Value(&newNodePort.Mtu, port.Mtu)
+		setSetStringValue(ctx, &newNodePort.Roles, port.Roles)
+		setStringValue(&newNodePort.Speed, port.Speed)
+		setFloat64Value(&newNodePort.SubInterfacesCount, port.SubInfCount)
+		setSetStringValue(ctx, &newNodePort.VlanIds, port.VlanIds)
+		setSetStringValue(ctx, &newNodePort.Vnis, port.Vnis)
+		setStringValue(&newNodePort.VrfId, port.VrfId)
+		setMetadataValue(ctx, &newNodePort.Metadata, port.Metadata)
+		setSetStringValue(ctx, &newNodePort.Labels, port.Labels)
+		if port.Annotations != nil {
+			newNodePort.Annotations = NewAnnotationsSet(ctx, port.Annotations)
 		}
 	} else {
 		newNodePort.Id = basetypes.NewStringNull()
@@ -708,76 +693,19 @@ func getAndSetNodePortAttributes(ctx context.Context, diags *diag.Diagnostics, c
 	*data = newNodePort
 }
 
-func getNodePortJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *NodePortResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-		payloadMap["enabled"] = data.Enabled.ValueBool()
-	}
-
-	if !data.Ipv4Addresses.IsNull() && !data.Ipv4Addresses.IsUnknown() {
-		payloadMap["ipv4Addresses"] = getSetStringJsonPayload(ctx, data.Ipv4Addresses)
-	}
-
-	if !data.Ipv6Addresses.IsNull() && !data.Ipv6Addresses.IsUnknown() {
-		payloadMap["ipv6Addresses"] = getSetStringJsonPayload(ctx, data.Ipv6Addresses)
-	}
-
-	if !data.PreventForwarding.IsNull() && !data.PreventForwarding.IsUnknown() {
-		payloadMap["linkDown"] = data.PreventForwarding.ValueBool()
-	}
-
-	if !data.Roles.IsNull() && !data.Roles.IsUnknown() {
-		payloadMap["roles"] = getSetStringJsonPayload(ctx, data.Roles)
-	}
-
-	if !data.VrfId.IsNull() && !data.VrfId.IsUnknown() {
-		payloadMap["vrfId"] = data.VrfId.ValueString()
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	}
-
-	if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-		payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	}
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"ports": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+func getNodePortPayload(ctx context.Context, data *NodePortResourceModel) *client.Port {
+	return &client.Port{
+		Name:          getStringPayload(data.Name),
+		Description:   getStringPayload(data.Description),
+		Enabled:       getBoolPayload(data.Enabled),
+		Ipv4Addresses: getSetStringPayload(ctx, data.Ipv4Addresses),
+		Ipv6Addresses: getSetStringPayload(ctx, data.Ipv6Addresses),
+		LinkDown:      getBoolPayload(data.PreventForwarding),
+		Roles:         getSetStringPayload(ctx, data.Roles),
+		VrfId:         getStringPayload(data.VrfId),
+		Labels:        getSetStringPayload(ctx, data.Labels),
+		Annotations:   getAnnotationsPayload(ctx, data.Annotations),
 	}
-	return jsonPayload
 }
 
 func checkAndSetNodePortIds(data *NodePortResourceModel) {
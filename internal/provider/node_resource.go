@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -33,6 +31,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NodeResource{}
 var _ resource.ResourceWithImportState = &NodeResource{}
+var _ resource.ResourceWithModifyPlan = &NodeResource{}
 
 func NewNodeResource() resource.Resource {
 	return &NodeResource{}
@@ -152,6 +151,10 @@ type NodeIdentifier struct {
 	Id types.String
 }
 
+func (r *NodeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *NodeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_node")
 	resp.TypeName = req.ProviderTypeName + "_node"
@@ -298,6 +301,8 @@ func (r *NodeResource) Configure(ctx context.Context, req resource.ConfigureRequ
 
 func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_node")
+	ctx, span := startSpan(ctx, "hyperfabric_node.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *NodeResourceModel
 
@@ -309,25 +314,16 @@ func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_node with name '%s'", data.Name.ValueString()))
 
-	jsonPayload := getNodeJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes", data.FabricId.ValueString()), "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.client.AddChangedFabric(data.FabricId.ValueString())
-	nodeContainer, err := container.ArrayElement(0, "nodes")
+	node, err := r.client.Nodes.Create(ctx, data.FabricId.ValueString(), getNodePayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.FabricId.ValueString())
 
-	nodeId := StripQuotes(nodeContainer.Search("nodeId").String())
-	if nodeId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", data.FabricId.ValueString(), nodeId))
-		data.NodeId = basetypes.NewStringValue(nodeId)
+	if node != nil && node.NodeId != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", data.FabricId.ValueString(), node.NodeId))
+		data.NodeId = basetypes.NewStringValue(node.NodeId)
 		getAndSetNodeAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -340,6 +336,8 @@ func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 func (r *NodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_node")
+	ctx, span := startSpan(ctx, "hyperfabric_node.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -365,6 +363,8 @@ func (r *NodeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 func (r *NodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_node")
+	ctx, span := startSpan(ctx, "hyperfabric_node.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeResourceModel
 	var stateData *NodeResourceModel
 
@@ -378,18 +378,12 @@ func (r *NodeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_node with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getNodeJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s", data.FabricId.ValueString(), data.NodeId.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	_, err := r.client.Nodes.Update(withRevision(ctx, stateData.Metadata), data.FabricId.ValueString(), data.NodeId.ValueString(), getNodePayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
-	r.client.AddChangedFabric(data.FabricId.ValueString())
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	getAndSetNodeAttributes(ctx, &resp.Diagnostics, r.client, data)
 
 	// Save updated data into Terraform state
@@ -399,6 +393,8 @@ func (r *NodeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 func (r *NodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_node")
+	ctx, span := startSpan(ctx, "hyperfabric_node.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -410,11 +406,12 @@ func (r *NodeResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_node with id '%s'", data.Id.ValueString()))
 	checkAndSetNodeIds(data)
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s", data.FabricId.ValueString(), data.NodeId.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	err := r.client.Nodes.Delete(withRevision(ctx, data.Metadata), data.FabricId.ValueString(), data.NodeId.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
-	r.client.AddChangedFabric(data.FabricId.ValueString())
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_node with id '%s'", data.Id.ValueString()))
 }
 
@@ -437,46 +434,32 @@ func (r *NodeResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 func getAndSetNodeAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *NodeResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/nodes/%s", data.FabricId.ValueString(), data.NodeId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	node, err := client.Nodes.Get(ctx, data.FabricId.ValueString(), data.NodeId.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newNode := *getNewNodeResourceModelFromData(data)
 
-	if requestData.Data() != nil {
-		attributes := requestData.Data().(map[string]interface{})
-		for attributeName, attributeValue := range attributes {
-			if attributeName == "nodeId" && (data.NodeId.IsNull() || data.NodeId.IsUnknown() || data.NodeId.ValueString() == "" || data.NodeId.ValueString() != attributeValue.(string)) {
-				newNode.NodeId = basetypes.NewStringValue(attributeValue.(string))
-				newNode.Id = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", newNode.FabricId.ValueString(), newNode.NodeId.ValueString()))
-			} else if attributeName == "fabricId" {
-			} else if attributeName == "name" {
-				newNode.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newNode.Description = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "enabled" {
-				newNode.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "location" {
-				newNode.Location = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "modelName" {
-				newNode.ModelName = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "serialNumber" {
-				newNode.SerialNumber = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "deviceId" {
-				newNode.DeviceId = basetypes.NewStringValue(attributeValue.(string))
-				// } else if attributeName == "position" {
-				// 	newNode.Position = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "roles" {
-				newNode.Roles = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "metadata" {
-				newNode.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newNode.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "annotations" {
-				newNode.Annotations = NewNodeAnnotationsSet(ctx, attributeValue.([]interface{}))
-				newNode.Position = NewPositionString(ctx, attributeValue.([]interface{}))
-			}
+	if node != nil {
+		if node.NodeId != "" && node.NodeId != data.NodeId.ValueString() {
+			newNode.NodeId = basetypes.NewStringValue(node.NodeId)
+			newNode.Id = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", newNode.FabricId.ValueString(), newNode.NodeId.ValueString()))
+		}
+		setStringValue(&newNode.Name, node.Name)
+		setStringValue(&newNode.Description, node.Description)
+		setBoolValue(&newNode.Enabled, node.Enabled)
+		setStringValue(&newNode.Location, node.Location)
+		setStringValue(&newNode.ModelName, node.ModelName)
+		setStringValue(&newNode.SerialNumber, node.SerialNumber)
+		setStringValue(&newNode.DeviceId, node.DeviceId)
+		setSetStringValue(ctx, &newNode.Roles, node.Roles)
+		setMetadataValue(ctx, &newNode.Metadata, node.Metadata)
+		setSetStringValue(ctx, &newNode.Labels, node.Labels)
+		if node.Annotations != nil {
+			newNode.Annotations = NewNodeAnnotationsSet(ctx, node.Annotations)
+			newNode.Position = NewPositionString(ctx, node.Annotations)
 		}
 	} else {
 		newNode.Id = basetypes.NewStringNull()
@@ -484,93 +467,37 @@ func getAndSetNodeAttributes(ctx context.Context, diags *diag.Diagnostics, clien
 	*data = newNode
 }
 
-func getNodeJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *NodeResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	// FIXME: REMOVE when PUT issue fixed
-	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-		payloadMap["enabled"] = data.Enabled.ValueBool()
-	}
-
-	if !data.Location.IsNull() && !data.Location.IsUnknown() {
-		payloadMap["location"] = data.Location.ValueString()
-	}
-
-	if !data.ModelName.IsNull() && !data.ModelName.IsUnknown() {
-		payloadMap["modelName"] = data.ModelName.ValueString()
-	}
-
-	if !data.SerialNumber.IsNull() && !data.SerialNumber.IsUnknown() {
-		payloadMap["serialNumber"] = data.SerialNumber.ValueString()
-	}
-
-	if !data.DeviceId.IsNull() && !data.DeviceId.IsUnknown() {
-		payloadMap["deviceId"] = data.DeviceId.ValueString()
-	}
-
-	if !data.Roles.IsNull() && !data.Roles.IsUnknown() {
-		payloadMap["roles"] = getSetStringJsonPayload(ctx, data.Roles)
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
+func getNodePayload(ctx context.Context, data *NodeResourceModel) *client.Node {
+	node := &client.Node{
+		Name:         getStringPayload(data.Name),
+		Description:  getStringPayload(data.Description),
+		Enabled:      getBoolPayload(data.Enabled), // FIXME: REMOVE when PUT issue fixed
+		Location:     getStringPayload(data.Location),
+		ModelName:    getStringPayload(data.ModelName),
+		SerialNumber: getStringPayload(data.SerialNumber),
+		DeviceId:     getStringPayload(data.DeviceId),
+		Roles:        getSetStringPayload(ctx, data.Roles),
+		Labels:       getSetStringPayload(ctx, data.Labels),
 	}
 
 	if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-		annotations := getAnnotationsJsonPayload(ctx, data.Annotations)
+		node.Annotations = getAnnotationsJsonPayload(ctx, data.Annotations)
 		if !data.Position.IsNull() && !data.Position.IsUnknown() {
-			annotations = append(annotations, map[string]string{
-				"name":     "position",
-				"value":    data.Position.ValueString(),
-				"dataType": "STRING",
+			node.Annotations = append(node.Annotations, client.Annotation{
+				Name:     "position",
+				Value:    data.Position.ValueString(),
+				DataType: "STRING",
 			})
 		}
-		payloadMap["annotations"] = annotations
-	}
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"nodes": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
 	}
-	return jsonPayload
+	return node
 }
 
-func NewPositionString(ctx context.Context, data []interface{}) basetypes.StringValue {
+func NewPositionString(ctx context.Context, data []client.Annotation) basetypes.StringValue {
 	var position string
 	for _, annotation := range data {
-		newAnnotation := NewAnnotationResourceModel(annotation.(map[string]interface{}))
-		if newAnnotation.Name.ValueString() == "position" {
-			position = newAnnotation.Value.ValueString()
+		if annotation.Name == "position" {
+			position = annotation.Value
 		}
 	}
 	return basetypes.NewStringValue(position)
@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -111,6 +111,8 @@ func (d *NodeSubInterfaceDataSource) Configure(ctx context.Context, req datasour
 
 func (d *NodeSubInterfaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_node_sub_interface")
+	ctx, span := startSpan(ctx, "data.hyperfabric_node_sub_interface.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeSubInterfaceResourceModel
 
 	// Read Terraform configuration data into the model
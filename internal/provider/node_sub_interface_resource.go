@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -31,6 +29,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NodeSubInterfaceResource{}
 var _ resource.ResourceWithImportState = &NodeSubInterfaceResource{}
+var _ resource.ResourceWithModifyPlan = &NodeSubInterfaceResource{}
 
 func NewNodeSubInterfaceResource() resource.Resource {
 	return &NodeSubInterfaceResource{}
@@ -144,6 +143,10 @@ type NodeSubInterfaceIdentifier struct {
 	Id types.String
 }
 
+func (r *NodeSubInterfaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *NodeSubInterfaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_node_sub_interface")
 	resp.TypeName = req.ProviderTypeName + "_node_sub_interface"
@@ -304,6 +307,8 @@ func (r *NodeSubInterfaceResource) Configure(ctx context.Context, req resource.C
 
 func (r *NodeSubInterfaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_node_sub_interface")
+	ctx, span := startSpan(ctx, "hyperfabric_node_sub_interface.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *NodeSubInterfaceResourceModel
 
@@ -315,25 +320,17 @@ func (r *NodeSubInterfaceResource) Create(ctx context.Context, req resource.Crea
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_node_sub_interface with name '%s'", data.Name.ValueString()))
 
-	jsonPayload := getNodeSubInterfaceJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/subInterfaces", data.NodeId.ValueString()), "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	subInterfaceContainer, err := container.ArrayElement(0, "subInterfaces")
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	subInterface, err := r.client.SubInterfaces.Create(ctx, fabricId, nodeId, getNodeSubInterfacePayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.NodeId.ValueString())
 
-	subInterfaceId := StripQuotes(subInterfaceContainer.Search("id").String())
-	if subInterfaceId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", data.NodeId.ValueString(), subInterfaceId))
-		data.SubInterfaceId = basetypes.NewStringValue(subInterfaceId)
+	if subInterface != nil && subInterface.Id != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", data.NodeId.ValueString(), subInterface.Id))
+		data.SubInterfaceId = basetypes.NewStringValue(subInterface.Id)
 		getAndSetNodeSubInterfaceAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -346,6 +343,8 @@ func (r *NodeSubInterfaceResource) Create(ctx context.Context, req resource.Crea
 
 func (r *NodeSubInterfaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_node_sub_interface")
+	ctx, span := startSpan(ctx, "hyperfabric_node_sub_interface.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeSubInterfaceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -371,6 +370,8 @@ func (r *NodeSubInterfaceResource) Read(ctx context.Context, req resource.ReadRe
 
 func (r *NodeSubInterfaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_node_sub_interface")
+	ctx, span := startSpan(ctx, "hyperfabric_node_sub_interface.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeSubInterfaceResourceModel
 	var stateData *NodeSubInterfaceResourceModel
 
@@ -384,17 +385,13 @@ func (r *NodeSubInterfaceResource) Update(ctx context.Context, req resource.Upda
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_node_sub_interface with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getNodeSubInterfaceJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/subInterfaces/%s", data.NodeId.ValueString(), data.SubInterfaceId.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	_, err := r.client.SubInterfaces.Update(withRevision(ctx, stateData.Metadata), fabricId, nodeId, data.SubInterfaceId.ValueString(), getNodeSubInterfacePayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 
 	getAndSetNodeSubInterfaceAttributes(ctx, &resp.Diagnostics, r.client, data)
 
@@ -405,6 +402,8 @@ func (r *NodeSubInterfaceResource) Update(ctx context.Context, req resource.Upda
 
 func (r *NodeSubInterfaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_node_sub_interface")
+	ctx, span := startSpan(ctx, "hyperfabric_node_sub_interface.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *NodeSubInterfaceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -416,10 +415,13 @@ func (r *NodeSubInterfaceResource) Delete(ctx context.Context, req resource.Dele
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_node_sub_interface with id '%s'", data.Id.ValueString()))
 	checkAndSetNodeSubInterfaceIds(data)
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/subInterfaces/%s", data.NodeId.ValueString(), data.SubInterfaceId.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	err := r.client.SubInterfaces.Delete(withRevision(ctx, data.Metadata), fabricId, nodeId, data.SubInterfaceId.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_node_sub_interface with id '%s'", data.Id.ValueString()))
 }
 
@@ -433,8 +435,10 @@ func (r *NodeSubInterfaceResource) ImportState(ctx context.Context, req resource
 }
 
 func getAndSetNodeSubInterfaceAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *NodeSubInterfaceResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/subInterfaces/%s", data.NodeId.ValueString(), data.SubInterfaceId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	fabricId, nodeId := splitNodeId(data.NodeId.ValueString())
+	subInterface, err := client.SubInterfaces.Get(ctx, fabricId, nodeId, data.SubInterfaceId.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
@@ -443,42 +447,33 @@ func getAndSetNodeSubInterfaceAttributes(ctx context.Context, diags *diag.Diagno
 	node.Id = newNodeSubInterface.NodeId
 	checkAndSetNodeIds(node)
 
-	if requestData.Data() != nil {
-		for attributeName, attributeValue := range requestData.Data().(map[string]interface{}) {
-			if attributeName == "id" && (data.SubInterfaceId.IsNull() || data.SubInterfaceId.IsUnknown() || data.SubInterfaceId.ValueString() == "" || data.SubInterfaceId.ValueString() != attributeValue.(string)) {
-				newNodeSubInterface.SubInterfaceId = basetypes.NewStringValue(attributeValue.(string))
-				newNodeSubInterface.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", newNodeSubInterface.NodeId.ValueString(), newNodeSubInterface.SubInterfaceId.ValueString()))
-			} else if attributeName == "fabricId" && (node.FabricId.IsNull() || node.FabricId.IsUnknown() || node.FabricId.ValueString() == "" || node.FabricId.ValueString() != attributeValue.(string)) {
-				node.FabricId = basetypes.NewStringValue(attributeValue.(string))
-				newNodeSubInterface.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
-				newNodeSubInterface.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", newNodeSubInterface.NodeId.ValueString(), newNodeSubInterface.SubInterfaceId.ValueString()))
-			} else if attributeName == "nodeId" && (node.NodeId.IsNull() || node.NodeId.IsUnknown() || node.NodeId.ValueString() == "" || node.NodeId.ValueString() != attributeValue.(string)) {
-				node.NodeId = basetypes.NewStringValue(attributeValue.(string))
-				newNodeSubInterface.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
-				newNodeSubInterface.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", newNodeSubInterface.NodeId.ValueString(), newNodeSubInterface.SubInterfaceId.ValueString()))
-			} else if attributeName == "name" {
-				newNodeSubInterface.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newNodeSubInterface.Description = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "enabled" {
-				newNodeSubInterface.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "ipv4Addresses" {
-				newNodeSubInterface.Ipv4Addresses = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "ipv6Addresses" {
-				newNodeSubInterface.Ipv6Addresses = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "vlanId" {
-				newNodeSubInterface.VlanId = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "vrfId" {
-				newNodeSubInterface.VrfId = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "parent" {
-				newNodeSubInterface.Parent = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "metadata" {
-				newNodeSubInterface.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newNodeSubInterface.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "annotations" {
-				newNodeSubInterface.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+	if subInterface != nil {
+		if subInterface.Id != "" && subInterface.Id != data.SubInterfaceId.ValueString() {
+			newNodeSubInterface.SubInterfaceId = basetypes.NewStringValue(subInterface.Id)
+			newNodeSubInterface.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", newNodeSubInterface.NodeId.ValueString(), newNodeSubInterface.SubInterfaceId.ValueString()))
+		}
+		if subInterface.FabricId != "" && subInterface.FabricId != node.FabricId.ValueString() {
+			node.FabricId = basetypes.NewStringValue(subInterface.FabricId)
+			newNodeSubInterface.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
+			newNodeSubInterface.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", newNodeSubInterface.NodeId.ValueString(), newNodeSubInterface.SubInterfaceId.ValueString()))
+		}
+		if subInterface.NodeId != "" && subInterface.NodeId != node.NodeId.ValueString() {
+			node.NodeId = basetypes.NewStringValue(subInterface.NodeId)
+			newNodeSubInterface.NodeId = basetypes.NewStringValue(fmt.Sprintf("%s/nodes/%s", node.FabricId.ValueString(), node.NodeId.ValueString()))
+			newNodeSubInterface.Id = basetypes.NewStringValue(fmt.Sprintf("%s/subInterfaces/%s", newNodeSubInterface.NodeId.ValueString(), newNodeSubInterface.SubInterfaceId.ValueString()))
+		}
+		setStringValue(&newNodeSubInterface.Name, subInterface.Name)
+		setStringValue(&newNodeSubInterface.Description, subInterface.Description)
+		setBoolValue(&newNodeSubInterface.Enabled, subInterface.Enabled)
+		setSetStringValue(ctx, &newNodeSubInterface.Ipv4Addresses, subInterface.Ipv4Addresses)
+		setSetStringValue(ctx, &newNodeSubInterface.Ipv6Addresses, subInterface.Ipv6Addresses)
+		setFloat64Value(&newNodeSubInterface.VlanId, subInterface.VlanId)
+		setStringValue(&newNodeSubInterface.VrfId, subInterface.VrfId)
+		setStringValue(&newNodeSubInterface.Parent, subInterface.Parent)
+		setMetadataValue(ctx, &newNodeSubInterface.Metadata, subInterface.Metadata)
+		setSetStringValue(ctx, &newNodeSubInterface.Labels, subInterface.Labels)
+		if subInterface.Annotations != nil {
+			newNodeSubInterface.Annotations = NewAnnotationsSet(ctx, subInterface.Annotations)
 		}
 	} else {
 		newNodeSubInterface.Id = basetypes.NewStringNull()
@@ -486,72 +481,18 @@ func getAndSetNodeSubInterfaceAttributes(ctx context.Context, diags *diag.Diagno
 	*data = newNodeSubInterface
 }
 
-func getNodeSubInterfaceJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *NodeSubInterfaceResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-		payloadMap["enabled"] = data.Enabled.ValueBool()
-	}
-
-	if !data.Ipv4Addresses.IsNull() && !data.Ipv4Addresses.IsUnknown() {
-		payloadMap["ipv4Addresses"] = getSetStringJsonPayload(ctx, data.Ipv4Addresses)
-	}
-
-	if !data.Ipv6Addresses.IsNull() && !data.Ipv6Addresses.IsUnknown() {
-		payloadMap["ipv6Addresses"] = getSetStringJsonPayload(ctx, data.Ipv6Addresses)
-	}
-
-	if !data.VlanId.IsNull() && !data.VlanId.IsUnknown() {
-		payloadMap["vlanId"] = data.VlanId.ValueFloat64()
-	}
-
-	if !data.VrfId.IsNull() && !data.VrfId.IsUnknown() {
-		payloadMap["vrfId"] = data.VrfId.ValueString()
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	}
-
-	if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-		payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	}
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"subInterfaces": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+func getNodeSubInterfacePayload(ctx context.Context, data *NodeSubInterfaceResourceModel) *client.SubInterface {
+	return &client.SubInterface{
+		Name:          getStringPayload(data.Name),
+		Description:   getStringPayload(data.Description),
+		Enabled:       getBoolPayload(data.Enabled),
+		Ipv4Addresses: getSetStringPayload(ctx, data.Ipv4Addresses),
+		Ipv6Addresses: getSetStringPayload(ctx, data.Ipv6Addresses),
+		VlanId:        getFloat64Payload(data.VlanId),
+		VrfId:         getStringPayload(data.VrfId),
+		Labels:        getSetStringPayload(ctx, data.Labels),
+		Annotations:   getAnnotationsPayload(ctx, data.Annotations),
 	}
-	return jsonPayload
 }
 
 func checkAndSetNodeSubInterfaceIds(data *NodeSubInterfaceResourceModel) {
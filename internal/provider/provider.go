@@ -12,11 +12,15 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,7 +29,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-var globalLabel string
+// configuredClients holds the clients of every provider instance configured in this process, including the aliased
+// ones, so that DoAutoCommit commits the changes made through each of them and ReportStats reports their usage of
+// the Hyperfabric API.
+var configuredClients struct {
+	sync.Mutex
+	clients []*client.Client
+}
 
 // Ensure HyperfabricProvider satisfies various provider interfaces.
 var _ provider.Provider = &HyperfabricProvider{}
@@ -37,6 +47,7 @@ type HyperfabricProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+	label   string
 	client  *client.Client
 }
 
@@ -47,9 +58,43 @@ type HyperfabricProviderModel struct {
 	MaxRetries types.Int32  `tfsdk:"retries"`
 	ProxyUrl   types.String `tfsdk:"proxy_url"`
 	ProxyCreds types.String `tfsdk:"proxy_creds"`
+	NoProxy    types.String `tfsdk:"no_proxy"`
 	Token      types.String `tfsdk:"token"`
 	URL        types.String `tfsdk:"url"`
 	AutoCommit types.Bool   `tfsdk:"auto_commit"`
+	// Candidate configuration committed by the auto-commit
+	Candidate types.String `tfsdk:"candidate"`
+	// Credential helpers providing the token
+	TokenCommand types.String `tfsdk:"token_command"`
+	TokenFile    types.String `tfsdk:"token_file"`
+	// OAuth2 client credentials of the provider authenticating without a static token
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	TokenUrl     types.String `tfsdk:"token_url"`
+	Scopes       types.Set    `tfsdk:"scopes"`
+	// Certificates of the certificate authorities trusted in addition to those of the system, and client certificate
+	CaCertPem     types.String `tfsdk:"ca_cert_pem"`
+	CaCertFile    types.String `tfsdk:"ca_cert_file"`
+	ClientCertPem types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPem  types.String `tfsdk:"client_key_pem"`
+	// Timeout of the REST API calls and exponential backoff between their retries
+	RequestTimeout     types.Int32   `tfsdk:"request_timeout"`
+	BackoffMinDelay    types.Int32   `tfsdk:"backoff_min_delay"`
+	BackoffMaxDelay    types.Int32   `tfsdk:"backoff_max_delay"`
+	BackoffDelayFactor types.Float64 `tfsdk:"backoff_delay_factor"`
+	// RetryOnConflict retries the updates and deletes rejected because of a modification outside of Terraform
+	RetryOnConflict types.Bool `tfsdk:"retry_on_conflict"`
+	// BatchCreates coalesces the concurrent creations in the same collection into a single REST API call
+	BatchCreates types.Bool `tfsdk:"batch_creates"`
+	// SkipLoggingPayload omits the payloads of the REST API calls from the logs
+	SkipLoggingPayload types.Bool `tfsdk:"skip_logging_payload"`
+	// Client-side limits shared by every resource and data source of the provider
+	MaxConcurrentRequests types.Int32   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	// CacheGetRequests caches the responses of the GET REST API calls for the life of the provider
+	CacheGetRequests types.Bool `tfsdk:"cache_get_requests"`
+	// ExperimentalCandidateApi enables the undocumented API of the hyperfabric_fabric_candidate data source
+	ExperimentalCandidateApi types.Bool `tfsdk:"experimental_candidate_api"`
 }
 
 func (p *HyperfabricProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -69,13 +114,17 @@ func (p *HyperfabricProvider) Schema(ctx context.Context, req provider.SchemaReq
 				Optional:            true,
 			},
 			"proxy_url": schema.StringAttribute{
-				MarkdownDescription: "Proxy Server URL with port number. This can also be set as the HYPERFABRIC_PROXY_URL environment variable.",
+				MarkdownDescription: "Proxy Server URL with port number, with the `http`, `https`, `socks5` or `socks5h` scheme. When not set, the proxies of the HTTPS_PROXY and HTTP_PROXY environment variables are used. This can also be set as the HYPERFABRIC_PROXY_URL environment variable.",
 				Optional:            true,
 			},
 			"proxy_creds": schema.StringAttribute{
 				MarkdownDescription: "Proxy server credentials in the form of username:password. This can also be set as the HYPERFABRIC_PROXY_CREDS environment variable.",
 				Optional:            true,
 			},
+			"no_proxy": schema.StringAttribute{
+				MarkdownDescription: "Comma-separated hosts, domains, IP addresses and CIDR ranges reached without proxy, replacing the NO_PROXY environment variable. This can also be set as the HYPERFABRIC_NO_PROXY environment variable.",
+				Optional:            true,
+			},
 			"retries": schema.Int32Attribute{
 				MarkdownDescription: "Number of retries for REST API calls. This can also be set as the HYPERFABRIC_RETRIES environment variable. Defaults to `2`.",
 				Optional:            true,
@@ -83,10 +132,105 @@ func (p *HyperfabricProvider) Schema(ctx context.Context, req provider.SchemaReq
 					int32validator.Between(0, 10),
 				},
 			},
+			"request_timeout": schema.Int32Attribute{
+				MarkdownDescription: fmt.Sprintf("Timeout in seconds of each attempt of the REST API calls. This can also be set as the HYPERFABRIC_REQUEST_TIMEOUT environment variable. Defaults to `%d`.", client.DefaultReqTimeoutVal),
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"backoff_min_delay": schema.Int32Attribute{
+				MarkdownDescription: fmt.Sprintf("Minimum delay in seconds before the retry of a REST API call. This can also be set as the HYPERFABRIC_BACKOFF_MIN_DELAY environment variable. Defaults to `%d`.", client.DefaultBackoffMinDelay),
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"backoff_max_delay": schema.Int32Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum delay in seconds before the retry of a REST API call, including the delays requested by the Retry-After header of the responses. This can also be set as the HYPERFABRIC_BACKOFF_MAX_DELAY environment variable. Defaults to `%d`.", client.DefaultBackoffMaxDelay),
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"backoff_delay_factor": schema.Float64Attribute{
+				MarkdownDescription: fmt.Sprintf("Factor by which the delay before the retry of a REST API call grows after each attempt. This can also be set as the HYPERFABRIC_BACKOFF_DELAY_FACTOR environment variable. Defaults to `%v`.", client.DefaultBackoffDelayFactor),
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(1),
+				},
+			},
 			"token": schema.StringAttribute{
 				MarkdownDescription: "API token of user in a the Hyperfabric service organization. This can also be set as the HYPERFABRIC_TOKEN environment variable.",
 				Sensitive:           true,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_command"), path.MatchRoot("token_file"), path.MatchRoot("client_id")),
+				},
+			},
+			"token_command": schema.StringAttribute{
+				MarkdownDescription: "Command run by the shell of the system to obtain the token, used instead of `token`. The command writes the token to its standard output, or a JSON object with the token in its `token` field and optionally its expiry as an RFC 3339 time in its `expires_at` field. The token is cached and the command is run again when the token is about to expire or is rejected. This can also be set as the HYPERFABRIC_TOKEN_COMMAND environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_file"), path.MatchRoot("client_id")),
+				},
+			},
+			"token_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file holding the token, used instead of `token`, in the format of the output of `token_command`. The token is cached and the file is read again when the token is about to expire or is rejected. This can also be set as the HYPERFABRIC_TOKEN_FILE environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_id")),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID of the OAuth2 client credentials of the provider, used instead of `token` to request the tokens of the provider from `token_url`. This can also be set as the HYPERFABRIC_CLIENT_ID environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_secret"), path.MatchRoot("token_url")),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "Client secret of the OAuth2 client credentials of `client_id`. This can also be set as the HYPERFABRIC_CLIENT_SECRET environment variable.",
+				Sensitive:           true,
+				Optional:            true,
+			},
+			"token_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the OAuth2 token endpoint issuing the tokens of `client_id`. This can also be set as the HYPERFABRIC_TOKEN_URL environment variable.",
+				Optional:            true,
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "Scopes of the OAuth2 tokens requested for `client_id`. This can also be set as the HYPERFABRIC_SCOPES environment variable, separated by spaces or commas.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificates of the certificate authorities trusted to verify the certificate of the Hyperfabric service, in addition to the certificate authorities of the system. This can also be set as the HYPERFABRIC_CA_CERT_PEM environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file of PEM encoded certificates of the certificate authorities trusted to verify the certificate of the Hyperfabric service, in addition to the certificate authorities of the system. This can also be set as the HYPERFABRIC_CA_CERT_FILE environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate presented to the Hyperfabric service, with the private key of `client_key_pem`. The client certificate authenticates the provider when no token is set. This can also be set as the HYPERFABRIC_CLIENT_CERT_PEM environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate of `client_cert_pem`. This can also be set as the HYPERFABRIC_CLIENT_KEY_PEM environment variable.",
+				Sensitive:           true,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
 			},
 			"url": schema.StringAttribute{
 				MarkdownDescription: "URL of the Hyperfabric service. This can also be set as the HYPERFABRIC_URL environment variable. Defaults to `https://hyperfabric.cisco.com`.",
@@ -96,6 +240,44 @@ func (p *HyperfabricProvider) Schema(ctx context.Context, req provider.SchemaReq
 				MarkdownDescription: "Automatically commit changes to the running configuration. This can also be set as the HYPERFABRIC_AUTO_COMMIT environment variable. Defaults to `false`.",
 				Optional:            true,
 			},
+			"candidate": schema.StringAttribute{
+				MarkdownDescription: "Name of the candidate configuration of the fabrics committed by `auto_commit` and, by default, by the `hyperfabric_fabric_commit` resource. This can also be set as the HYPERFABRIC_CANDIDATE environment variable. Defaults to `default`.",
+				Optional:            true,
+			},
+			"retry_on_conflict": schema.BoolAttribute{
+				MarkdownDescription: "Re-read the object and retry automatically when an update or delete is rejected because the object was modified outside of Terraform since it was last read, overwriting that modification. This can also be set as the HYPERFABRIC_RETRY_ON_CONFLICT environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"batch_creates": schema.BoolAttribute{
+				MarkdownDescription: "Coalesce the creations of objects in the same collection, such as the VNIs of a fabric, requested within 100 milliseconds into a single REST API call of up to 100 objects. When the call is rejected because of an invalid object, the objects are created one by one. This can also be set as the HYPERFABRIC_BATCH_CREATES environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"skip_logging_payload": schema.BoolAttribute{
+				MarkdownDescription: "Omit the payloads of the REST API calls from the logs. Sensitive values such as tokens and passwords are always masked. This can also be set as the HYPERFABRIC_SKIP_LOGGING_PAYLOAD environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int32Attribute{
+				MarkdownDescription: "Maximum number of REST API calls in flight at the same time, shared by all resources and data sources. This can also be set as the HYPERFABRIC_MAX_CONCURRENT_REQUESTS environment variable. Defaults to `0` (unlimited).",
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum rate of REST API calls per second, including retries, shared by all resources and data sources. This can also be set as the HYPERFABRIC_REQUESTS_PER_SECOND environment variable. Defaults to `0` (unlimited).",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"cache_get_requests": schema.BoolAttribute{
+				MarkdownDescription: "Cache the responses of the GET REST API calls for the life of the provider and send the concurrent identical calls once. The cached responses, including the objects not found, are invalidated by the changes made through the provider, but not by the changes made outside of Terraform during the run. This can also be set as the HYPERFABRIC_CACHE_GET_REQUESTS environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"experimental_candidate_api": schema.BoolAttribute{
+				MarkdownDescription: "Enable the `hyperfabric_fabric_candidate` data source. The endpoint it reads the pending changes of a candidate configuration from is not documented by the Hyperfabric API, so it may change or be missing. This can also be set as the HYPERFABRIC_EXPERIMENTAL_CANDIDATE_API environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -112,6 +294,16 @@ func getStringAttribute(attribute basetypes.StringValue, envKey string, defaultV
 	return attribute.ValueString()
 }
 
+// getCredentialAttributes returns the token, token_command, token_file and client_id attributes of the provider. They
+// are read from the environment variables only when none of them is set in the configuration, so that a credential
+// of the configuration is not replaced by a source of tokens of the environment.
+func getCredentialAttributes(data HyperfabricProviderModel) (string, string, string, string) {
+	if data.Token.IsNull() && data.TokenCommand.IsNull() && data.TokenFile.IsNull() && data.ClientId.IsNull() {
+		return os.Getenv("HYPERFABRIC_TOKEN"), os.Getenv("HYPERFABRIC_TOKEN_COMMAND"), os.Getenv("HYPERFABRIC_TOKEN_FILE"), os.Getenv("HYPERFABRIC_CLIENT_ID")
+	}
+	return data.Token.ValueString(), data.TokenCommand.ValueString(), data.TokenFile.ValueString(), data.ClientId.ValueString()
+}
+
 func getBoolAttribute(attribute basetypes.BoolValue, envKey string, defaultValue bool) bool {
 	if attribute.IsNull() {
 		envValue, err := strconv.ParseBool(os.Getenv(envKey))
@@ -134,6 +326,17 @@ func getIntAttribute(attribute basetypes.Int32Value, envKey string, defaultValue
 	return int(attribute.ValueInt32())
 }
 
+func getFloatAttribute(attribute basetypes.Float64Value, envKey string, defaultValue float64) float64 {
+	if attribute.IsNull() {
+		envValue, err := strconv.ParseFloat(os.Getenv(envKey), 64)
+		if err != nil {
+			return defaultValue
+		}
+		return envValue
+	}
+	return attribute.ValueFloat64()
+}
+
 func (p *HyperfabricProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data HyperfabricProviderModel
 
@@ -150,19 +353,135 @@ func (p *HyperfabricProvider) Configure(ctx context.Context, req provider.Config
 		)
 	}
 
-	token := getStringAttribute(data.Token, "HYPERFABRIC_TOKEN", "")
+	token, tokenCommand, tokenFile, clientId := getCredentialAttributes(data)
 	insecure := getBoolAttribute(data.IsInsecure, "HYPERFABRIC_INSECURE", false)
 	maxRetries := getIntAttribute(data.MaxRetries, "HYPERFABRIC_RETRIES", 2)
+	requestTimeout := getIntAttribute(data.RequestTimeout, "HYPERFABRIC_REQUEST_TIMEOUT", client.DefaultReqTimeoutVal)
+	backoffMinDelay := getIntAttribute(data.BackoffMinDelay, "HYPERFABRIC_BACKOFF_MIN_DELAY", client.DefaultBackoffMinDelay)
+	backoffMaxDelay := getIntAttribute(data.BackoffMaxDelay, "HYPERFABRIC_BACKOFF_MAX_DELAY", client.DefaultBackoffMaxDelay)
+	backoffDelayFactor := getFloatAttribute(data.BackoffDelayFactor, "HYPERFABRIC_BACKOFF_DELAY_FACTOR", client.DefaultBackoffDelayFactor)
 	proxyCreds := getStringAttribute(data.ProxyCreds, "HYPERFABRIC_PROXY_CREDS", "")
 	proxyUrl := getStringAttribute(data.ProxyUrl, "HYPERFABRIC_PROXY_URL", "")
-	globalLabel = getStringAttribute(data.Label, "HYPERFABRIC_LABEL", "terraform")
+	noProxy := getStringAttribute(data.NoProxy, "HYPERFABRIC_NO_PROXY", "")
+	clientSecret := getStringAttribute(data.ClientSecret, "HYPERFABRIC_CLIENT_SECRET", "")
+	tokenUrl := getStringAttribute(data.TokenUrl, "HYPERFABRIC_TOKEN_URL", "")
+	scopes := strings.Fields(strings.ReplaceAll(os.Getenv("HYPERFABRIC_SCOPES"), ",", " "))
+	if !data.Scopes.IsNull() {
+		scopes = getSetStringJsonPayload(ctx, data.Scopes)
+	}
+	caCert := getStringAttribute(data.CaCertPem, "HYPERFABRIC_CA_CERT_PEM", "")
+	caCertFile := getStringAttribute(data.CaCertFile, "HYPERFABRIC_CA_CERT_FILE", "")
+	clientCert := getStringAttribute(data.ClientCertPem, "HYPERFABRIC_CLIENT_CERT_PEM", "")
+	clientKey := getStringAttribute(data.ClientKeyPem, "HYPERFABRIC_CLIENT_KEY_PEM", "")
+	p.label = getStringAttribute(data.Label, "HYPERFABRIC_LABEL", "terraform")
 	autoCommit := getBoolAttribute(data.AutoCommit, "HYPERFABRIC_AUTO_COMMIT", false)
+	candidate := getStringAttribute(data.Candidate, "HYPERFABRIC_CANDIDATE", client.DefaultCandidate)
+	retryOnConflict := getBoolAttribute(data.RetryOnConflict, "HYPERFABRIC_RETRY_ON_CONFLICT", false)
+	batchCreates := getBoolAttribute(data.BatchCreates, "HYPERFABRIC_BATCH_CREATES", false)
+	skipLoggingPayload := getBoolAttribute(data.SkipLoggingPayload, "HYPERFABRIC_SKIP_LOGGING_PAYLOAD", false)
+	maxConcurrentRequests := getIntAttribute(data.MaxConcurrentRequests, "HYPERFABRIC_MAX_CONCURRENT_REQUESTS", 0)
+	requestsPerSecond := getFloatAttribute(data.RequestsPerSecond, "HYPERFABRIC_REQUESTS_PER_SECOND", 0)
+	cacheGetRequests := getBoolAttribute(data.CacheGetRequests, "HYPERFABRIC_CACHE_GET_REQUESTS", false)
+	experimentalCandidateApi := getBoolAttribute(data.ExperimentalCandidateApi, "HYPERFABRIC_EXPERIMENTAL_CANDIDATE_API", false)
+	if maxRetries < 0 || maxRetries > 10 {
+		resp.Diagnostics.AddError(
+			"Incorrect retries value",
+			fmt.Sprintf("The number of retries '%d' must be between 0 and 10", maxRetries),
+		)
+	}
+	if requestTimeout < 1 {
+		resp.Diagnostics.AddError(
+			"Incorrect request_timeout value",
+			fmt.Sprintf("The request timeout '%d' must be at least 1 second", requestTimeout),
+		)
+	}
+	if backoffMinDelay < 1 || backoffMaxDelay < backoffMinDelay {
+		resp.Diagnostics.AddError(
+			"Incorrect backoff delays",
+			fmt.Sprintf("The minimum backoff delay '%d' must be at least 1 second and at most the maximum backoff delay '%d'", backoffMinDelay, backoffMaxDelay),
+		)
+	}
+	if backoffDelayFactor < 1 {
+		resp.Diagnostics.AddError(
+			"Incorrect backoff_delay_factor value",
+			fmt.Sprintf("The backoff delay factor '%v' must be at least 1", backoffDelayFactor),
+		)
+	}
+	if maxConcurrentRequests < 0 {
+		resp.Diagnostics.AddError(
+			"Incorrect max_concurrent_requests value",
+			fmt.Sprintf("The maximum number of concurrent requests '%d' must be at least 0", maxConcurrentRequests),
+		)
+	}
+	if requestsPerSecond < 0 {
+		resp.Diagnostics.AddError(
+			"Incorrect requests_per_second value",
+			fmt.Sprintf("The maximum number of requests per second '%v' must be at least 0", requestsPerSecond),
+		)
+	}
+	if proxyUrl != "" {
+		if err := client.ValidateProxyUrl(proxyUrl); err != nil {
+			resp.Diagnostics.AddError(
+				"Incorrect proxy_url",
+				err.Error(),
+			)
+		}
+	}
+	if clientId != "" && (clientSecret == "" || tokenUrl == "") {
+		resp.Diagnostics.AddError(
+			"Incomplete OAuth2 client credentials",
+			"The client_secret and token_url of the OAuth2 client credentials of client_id are required",
+		)
+	} else if clientId != "" && !strings.HasPrefix(tokenUrl, "https://") && !strings.HasPrefix(tokenUrl, "http://") {
+		resp.Diagnostics.AddError(
+			"Incorrect token_url",
+			fmt.Sprintf("Token URL '%s' must start with 'https://' or 'http://'", tokenUrl),
+		)
+	}
+	if caCert == "" && caCertFile != "" {
+		content, err := os.ReadFile(caCertFile)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read ca_cert_file",
+				fmt.Sprintf("The CA certificate file '%s' cannot be read: %s", caCertFile, err),
+			)
+		}
+		caCert = string(content)
+	}
+	if err := client.ValidateCertificates(caCert, clientCert, clientKey); err != nil {
+		resp.Diagnostics.AddError(
+			"Incorrect certificate configuration",
+			fmt.Sprintf("The certificates of the provider configuration cannot be used: %s", err),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Client configuration for data sources and resources
-	hyperfabricClient := client.GetClient(url, token, client.Insecure(insecure), client.ProxyUrl(proxyUrl), client.ProxyCreds(proxyCreds), client.MaxRetries(maxRetries), client.AutoCommit(autoCommit))
+	// Each provider instance, such as an aliased provider for another organization, has a client of its own
+	options := []client.Option{client.Insecure(insecure), client.CACert(caCert), client.AdminCert(clientCert), client.PrivateKey(clientKey), client.ProxyUrl(proxyUrl), client.ProxyCreds(proxyCreds), client.NoProxy(noProxy), client.MaxRetries(maxRetries), client.ReqTimeout(uint32(requestTimeout)), client.BackoffMinDelay(backoffMinDelay), client.BackoffMaxDelay(backoffMaxDelay), client.BackoffDelayFactor(backoffDelayFactor), client.AutoCommit(autoCommit), client.Candidate(candidate), client.RetryOnConflict(retryOnConflict), client.BatchCreates(batchCreates), client.SkipLoggingPayload(skipLoggingPayload), client.MaxConcurrentRequests(maxConcurrentRequests), client.RequestsPerSecond(requestsPerSecond), client.CacheGetRequests(cacheGetRequests), client.ExperimentalCandidateAPI(experimentalCandidateApi)}
+	// The sources of tokens replace the static token, in the order of precedence of the OAuth2 client credentials,
+	// the token command and the token file, see getCredentialAttributes
+	switch {
+	case clientId != "":
+		token = ""
+		options = append(options, client.OAuth2ClientCredentials(clientId, clientSecret, tokenUrl, scopes))
+	case tokenCommand != "":
+		token = ""
+		options = append(options, client.TokenCommand(tokenCommand))
+	case tokenFile != "":
+		token = ""
+		options = append(options, client.TokenFile(tokenFile))
+	}
+	hyperfabricClient := client.NewClient(url, token, options...)
 	resp.DataSourceData = hyperfabricClient
 	resp.ResourceData = hyperfabricClient
 	p.client = hyperfabricClient
+
+	configuredClients.Lock()
+	configuredClients.clients = append(configuredClients.clients, hyperfabricClient)
+	configuredClients.Unlock()
 }
 
 func (p *HyperfabricProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -176,6 +495,7 @@ func (p *HyperfabricProvider) Resources(ctx context.Context) []func() resource.R
 		NewNodeSubInterfaceResource,
 		NewConnectionResource,
 		NewBindToNodeResource,
+		NewFabricCommitResource,
 		NewUserResource,
 		NewVrfResource,
 		NewVniResource,
@@ -187,6 +507,7 @@ func (p *HyperfabricProvider) DataSources(ctx context.Context) []func() datasour
 		NewBearerTokenDataSource,
 		NewDeviceDataSource,
 		NewFabricDataSource,
+		NewFabricCandidateDataSource,
 		NewNodeDataSource,
 		NewNodeManagementPortDataSource,
 		NewNodePortDataSource,
@@ -212,6 +533,28 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// DoAutoCommit commits the changes made through the provider instance when auto_commit is set.
 func (p *HyperfabricProvider) DoAutoCommit() {
 	p.client.DoAutoCommit()
 }
+
+// DoAutoCommit commits the changes made through every provider instance configured in this process with auto_commit set.
+// Each provider instance commits with its own URL, credentials and candidate.
+func DoAutoCommit() {
+	var wg sync.WaitGroup
+	for _, c := range getConfiguredClients() {
+		wg.Add(1)
+		go func(c *client.Client) {
+			defer wg.Done()
+			c.DoAutoCommit()
+		}(c)
+	}
+	wg.Wait()
+}
+
+// getConfiguredClients returns the clients of the provider instances configured in this process.
+func getConfiguredClients() []*client.Client {
+	configuredClients.Lock()
+	defer configuredClients.Unlock()
+	return append([]*client.Client(nil), configuredClients.clients...)
+}
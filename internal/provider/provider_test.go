@@ -7,12 +7,54 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
+// testAccFakeAPI is the in-memory Hyperfabric API of the acceptance tests when TF_ACC_HYPERFABRIC_FAKE_API is set.
+var testAccFakeAPI *fakeapi.Server
+
+// TestMain runs the acceptance tests against an in-memory Hyperfabric API when TF_ACC_HYPERFABRIC_FAKE_API is set,
+// so they do not need a Hyperfabric organization and token.
+func TestMain(m *testing.M) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		os.Exit(m.Run())
+	}
+	server := fakeapi.NewServer()
+	testAccFakeAPI = server
+	os.Setenv("HYPERFABRIC_URL", server.URL)
+	os.Setenv("HYPERFABRIC_TOKEN", server.Token)
+	os.Setenv("HYPERFABRIC_INSECURE", "true")
+	os.Setenv("TF_ACC_HYPERFABRIC_DEVICE_ID", fakeapi.DefaultDevices[0].DeviceId)
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
@@ -26,3 +68,800 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// newProviderConfig returns the configuration of the provider with the given attributes, the others being null.
+func newProviderConfig(t *testing.T, attributes map[string]tftypes.Value) tfsdk.Config {
+	ctx := context.Background()
+	schemaResponse := &provider.SchemaResponse{}
+	New("test")().Schema(ctx, provider.SchemaRequest{}, schemaResponse)
+	objectType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tfsdk.Config{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
+
+func TestConfigureAliases(t *testing.T) {
+	var lock sync.Mutex
+	commits := map[string][]string{}
+	newServer := func(name string) *httptest.Server {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			commits[name] = append(commits[name], r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+			lock.Unlock()
+			w.Write([]byte(`{}`))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	servers := map[string]*httptest.Server{"prod": newServer("prod"), "lab": newServer("lab")}
+
+	providers := map[string]*HyperfabricProvider{}
+	var wg sync.WaitGroup
+	for name, server := range servers {
+		p := New("test")().(*HyperfabricProvider)
+		providers[name] = p
+		config := newProviderConfig(t, map[string]tftypes.Value{
+			"url":         tftypes.NewValue(tftypes.String, server.URL),
+			"token":       tftypes.NewValue(tftypes.String, name+"-token"),
+			"insecure":    tftypes.NewValue(tftypes.Bool, true),
+			"auto_commit": tftypes.NewValue(tftypes.Bool, true),
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Errorf("unexpected error: %v", resp.Diagnostics)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if providers["prod"].client == providers["lab"].client {
+		t.Fatal("expected a client per provider instance")
+	}
+	providers["prod"].client.AddChangedFabric("prod-fabric")
+	providers["lab"].client.AddChangedFabric("lab-fabric")
+	DoAutoCommit()
+
+	for name := range servers {
+		expected := "POST /api/v1/fabrics/" + name + "-fabric/candidates/default Bearer " + name + "-token"
+		if len(commits[name]) != 1 || commits[name][0] != expected {
+			t.Errorf("expected %q on the %s service, got %q", expected, name, commits[name])
+		}
+	}
+
+	DoAutoCommit()
+	if len(commits["prod"]) != 1 || len(commits["lab"]) != 1 {
+		t.Errorf("expected the changes to be committed once, got %q", commits)
+	}
+}
+
+func TestAccProviderAliases(t *testing.T) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		t.Skip("Acceptance test of aliased providers requires TF_ACC_HYPERFABRIC_FAKE_API to be set")
+	}
+	servers := map[string]*fakeapi.Server{"default": fakeapi.NewServer(), "lab": fakeapi.NewServer()}
+	for name, server := range servers {
+		server.Token = name + "-token"
+		defer server.Close()
+	}
+	// The provider servers of ProtoV6ProviderFactories serve every provider configuration from a single server, so the
+	// aliases are run by the provider binary, for which Terraform starts a provider process per configuration.
+	t.Setenv("TF_CLI_CONFIG_FILE", testAccProviderBinaryCLIConfig(t))
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Create a fabric and a VNI through the default and the aliased provider and verify they are created in the organization of their provider.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Create a fabric and a VNI through the default and the aliased provider and verify they are created in the organization of their provider.")
+				},
+				Config:             testProviderAliasesHclConfig(servers, name),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hyperfabric_fabric.default", "name", name),
+					resource.TestCheckResourceAttr("hyperfabric_fabric.lab", "name", name),
+					testCheckFabricInServer("hyperfabric_fabric.default", servers["default"], servers["lab"]),
+					testCheckFabricInServer("hyperfabric_fabric.lab", servers["lab"], servers["default"]),
+					testCheckVniInServer("hyperfabric_vni.default", servers["default"], servers["lab"]),
+					testCheckVniInServer("hyperfabric_vni.lab", servers["lab"], servers["default"]),
+				),
+			},
+		},
+	})
+}
+
+// testAccProviderBinaryCLIConfig builds the provider binary and returns the path of a configuration of the Terraform
+// CLI running it instead of the provider of the registry.
+func testAccProviderBinaryCLIConfig(t *testing.T) string {
+	dir := t.TempDir()
+	build := exec.Command("go", "build", "-o", filepath.Join(dir, "terraform-provider-hyperfabric"), "../..")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("build of the provider failed: %v\n%s", err, output)
+	}
+	cliConfig := filepath.Join(dir, "terraformrc")
+	content := fmt.Sprintf(`
+provider_installation {
+  dev_overrides {
+    "cisco-open/hyperfabric" = %q
+  }
+  direct {}
+}
+`, dir)
+	if err := os.WriteFile(cliConfig, []byte(content), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return cliConfig
+}
+
+// testCheckFabricInServer checks that the fabric of resourceName exists in server only.
+func testCheckFabricInServer(resourceName string, server, otherServer *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fabric, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", resourceName)
+		}
+		ctx := context.Background()
+		if found, err := client.NewClient(server.URL, server.Token, client.Insecure(true)).Fabrics.Get(ctx, fabric.Primary.ID); found == nil {
+			return fmt.Errorf("expected %s in %s, got %v", resourceName, server.URL, err)
+		}
+		if found, err := client.NewClient(otherServer.URL, otherServer.Token, client.Insecure(true)).Fabrics.Get(ctx, fabric.Primary.ID); found != nil || err != nil {
+			return fmt.Errorf("expected %s not to be in %s, got %v", resourceName, otherServer.URL, err)
+		}
+		return nil
+	}
+}
+
+// testCheckVniInServer checks that the VNI of resourceName exists in server only.
+func testCheckVniInServer(resourceName string, server, otherServer *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vni, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", resourceName)
+		}
+		fabricId, vniId := vni.Primary.Attributes["fabric_id"], vni.Primary.Attributes["vni_id"]
+		ctx := context.Background()
+		if found, err := client.NewClient(server.URL, server.Token, client.Insecure(true)).Vnis.Get(ctx, fabricId, vniId); found == nil {
+			return fmt.Errorf("expected %s in %s, got %v", resourceName, server.URL, err)
+		}
+		if found, _ := client.NewClient(otherServer.URL, otherServer.Token, client.Insecure(true)).Vnis.Get(ctx, fabricId, vniId); found != nil {
+			return fmt.Errorf("expected %s not to be in %s", resourceName, otherServer.URL)
+		}
+		return nil
+	}
+}
+
+func testProviderAliasesHclConfig(servers map[string]*fakeapi.Server, name string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    hyperfabric = {
+      source = "cisco-open/hyperfabric"
+    }
+  }
+}
+
+provider "hyperfabric" {
+  url      = "%[1]s"
+  token    = "%[2]s"
+  insecure = true
+}
+
+provider "hyperfabric" {
+  alias    = "lab"
+  url      = "%[3]s"
+  token    = "%[4]s"
+  insecure = true
+}
+
+resource "hyperfabric_fabric" "default" {
+  name = "%[5]s"
+}
+
+resource "hyperfabric_vni" "default" {
+  fabric_id = hyperfabric_fabric.default.id
+  name      = "vni1"
+}
+
+resource "hyperfabric_fabric" "lab" {
+  provider = hyperfabric.lab
+  name     = "%[5]s"
+}
+
+resource "hyperfabric_vni" "lab" {
+  provider  = hyperfabric.lab
+  fabric_id = hyperfabric_fabric.lab.id
+  name      = "vni1"
+}
+`, servers["default"].URL, servers["default"].Token, servers["lab"].URL, servers["lab"].Token, name)
+}
+
+func TestAccProviderOAuth2ClientCredentials(t *testing.T) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		t.Skip("Acceptance test of the OAuth2 client credentials requires TF_ACC_HYPERFABRIC_FAKE_API to be set")
+	}
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify that invalid OAuth2 client credentials are rejected.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Verify that invalid OAuth2 client credentials are rejected.")
+				},
+				Config:      testProviderOAuth2HclConfig("wrong-secret", name),
+				ExpectError: regexp.MustCompile("invalid_client"),
+			},
+			// Create a fabric with a provider authenticated with OAuth2 client credentials.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Create a fabric with a provider authenticated with OAuth2 client credentials.")
+				},
+				Config:             testProviderOAuth2HclConfig(fakeapi.DefaultClientSecret, name),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hyperfabric_fabric.test", "name", name),
+				),
+			},
+		},
+	})
+}
+
+func testProviderOAuth2HclConfig(clientSecret, name string) string {
+	return fmt.Sprintf(`
+provider "hyperfabric" {
+  client_id     = "%[1]s"
+  client_secret = "%[2]s"
+  token_url     = "%[3]s"
+  scopes        = ["READ_WRITE"]
+}
+
+resource "hyperfabric_fabric" "test" {
+  name = "%[4]s"
+}
+`, fakeapi.DefaultClientId, clientSecret, os.Getenv("HYPERFABRIC_URL")+fakeapi.TokenPath, name)
+}
+
+func TestAccProviderTokenCommandAndFile(t *testing.T) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		t.Skip("Acceptance test of the token command and file requires TF_ACC_HYPERFABRIC_FAKE_API to be set")
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("Acceptance test of the token command requires a POSIX shell")
+	}
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(fmt.Sprintf(`{"token": "%s", "expires_at": "%s"}`, os.Getenv("HYPERFABRIC_TOKEN"), time.Now().Add(time.Hour).Format(time.RFC3339))), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify that the failure of the token command is returned.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Verify that the failure of the token command is returned.")
+				},
+				Config:      testProviderTokenHelperHclConfig("token_command", "echo 'session expired' >&2; exit 1", name),
+				ExpectError: regexp.MustCompile("session expired"),
+			},
+			// Create a fabric with a provider authenticated with the token of a command.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Create a fabric with a provider authenticated with the token of a command.")
+				},
+				Config:             testProviderTokenHelperHclConfig("token_command", fmt.Sprintf("printf '%%s' '%s'", os.Getenv("HYPERFABRIC_TOKEN")), name),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hyperfabric_fabric.test", "name", name),
+				),
+			},
+			// Verify the fabric with a provider authenticated with the token of a file.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Verify the fabric with a provider authenticated with the token of a file.")
+				},
+				Config:             testProviderTokenHelperHclConfig("token_file", tokenFile, name),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testProviderTokenHelperHclConfig(attribute, value, name string) string {
+	return fmt.Sprintf(`
+provider "hyperfabric" {
+  %[1]s = %[2]q
+}
+
+resource "hyperfabric_fabric" "test" {
+  name = "%[3]s"
+}
+`, attribute, value, name)
+}
+
+func TestConfigureTimeoutAndBackoff(t *testing.T) {
+	var lock sync.Mutex
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests++
+		first := requests == 1
+		lock.Unlock()
+		if r.URL.Path == "/api/v1/fabrics/slow" {
+			time.Sleep(2 * time.Second)
+		} else if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"fabricId": "f1"}`))
+	}))
+	defer server.Close()
+	t.Setenv("HYPERFABRIC_BACKOFF_DELAY_FACTOR", "1")
+
+	configure := func(attributes map[string]tftypes.Value) (*HyperfabricProvider, *provider.ConfigureResponse) {
+		attributes["url"] = tftypes.NewValue(tftypes.String, server.URL)
+		attributes["token"] = tftypes.NewValue(tftypes.String, "token")
+		attributes["insecure"] = tftypes.NewValue(tftypes.Bool, true)
+		p := New("test")().(*HyperfabricProvider)
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: newProviderConfig(t, attributes)}, resp)
+		return p, resp
+	}
+
+	p, resp := configure(map[string]tftypes.Value{
+		"retries":           tftypes.NewValue(tftypes.Number, 1),
+		"request_timeout":   tftypes.NewValue(tftypes.Number, 1),
+		"backoff_min_delay": tftypes.NewValue(tftypes.Number, 1),
+		"backoff_max_delay": tftypes.NewValue(tftypes.Number, 1),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	start := time.Now()
+	if _, err := p.client.Fabrics.Get(context.Background(), "f1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("expected a retry after the backoff delay of 1 second, got %s", elapsed)
+	}
+	start = time.Now()
+	if _, err := p.client.Fabrics.Get(context.Background(), "slow"); err == nil || time.Since(start) > 5*time.Second {
+		t.Errorf("expected the attempts to time out after the request timeout of 1 second, got %v after %s", err, time.Since(start))
+	}
+
+	t.Setenv("HYPERFABRIC_BACKOFF_MAX_DELAY", "2")
+	if _, resp := configure(map[string]tftypes.Value{"backoff_min_delay": tftypes.NewValue(tftypes.Number, 5)}); !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a minimum backoff delay above the maximum backoff delay")
+	}
+	t.Setenv("HYPERFABRIC_REQUEST_TIMEOUT", "0")
+	if _, resp := configure(map[string]tftypes.Value{}); !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a request timeout of 0 seconds")
+	}
+}
+
+func TestConfigureCredentialPrecedence(t *testing.T) {
+	var lock sync.Mutex
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		authorization = r.Header.Get("Authorization")
+		lock.Unlock()
+		w.Write([]byte(`{"fabricId": "f1"}`))
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("HYPERFABRIC_TOKEN", "env-token")
+	t.Setenv("HYPERFABRIC_TOKEN_FILE", tokenFile)
+
+	sentAuthorization := func(attributes map[string]tftypes.Value) string {
+		attributes["url"] = tftypes.NewValue(tftypes.String, server.URL)
+		attributes["insecure"] = tftypes.NewValue(tftypes.Bool, true)
+		p := New("test")().(*HyperfabricProvider)
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: newProviderConfig(t, attributes)}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		if _, err := p.client.Fabrics.Get(context.Background(), "f1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		return authorization
+	}
+
+	if got := sentAuthorization(map[string]tftypes.Value{"token": tftypes.NewValue(tftypes.String, "config-token")}); got != "Bearer config-token" {
+		t.Errorf("expected the token of the configuration to be used over HYPERFABRIC_TOKEN_FILE, got %q", got)
+	}
+	if got := sentAuthorization(map[string]tftypes.Value{}); got != "Bearer file-token" {
+		t.Errorf("expected the token of HYPERFABRIC_TOKEN_FILE over HYPERFABRIC_TOKEN without credential in the configuration, got %q", got)
+	}
+}
+
+func TestConfigureCacheGetRequests(t *testing.T) {
+	var lock sync.Mutex
+	gets := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		gets++
+		lock.Unlock()
+		w.Write([]byte(`{"fabricId": "f1"}`))
+	}))
+	defer server.Close()
+
+	sentGets := func(attributes map[string]tftypes.Value) int {
+		attributes["url"] = tftypes.NewValue(tftypes.String, server.URL)
+		attributes["token"] = tftypes.NewValue(tftypes.String, "token")
+		attributes["insecure"] = tftypes.NewValue(tftypes.Bool, true)
+		p := New("test")().(*HyperfabricProvider)
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: newProviderConfig(t, attributes)}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		lock.Lock()
+		gets = 0
+		lock.Unlock()
+		for i := 0; i < 2; i++ {
+			if _, err := p.client.Fabrics.Get(context.Background(), "f1"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		return gets
+	}
+
+	if got := sentGets(map[string]tftypes.Value{}); got != 2 {
+		t.Errorf("expected the GET requests not to be cached by default, got %d requests", got)
+	}
+	if got := sentGets(map[string]tftypes.Value{"cache_get_requests": tftypes.NewValue(tftypes.Bool, true)}); got != 1 {
+		t.Errorf("expected the responses of the GET requests to be cached when cache_get_requests is true, got %d requests", got)
+	}
+	t.Setenv("HYPERFABRIC_CACHE_GET_REQUESTS", "true")
+	if got := sentGets(map[string]tftypes.Value{}); got != 1 {
+		t.Errorf("expected the responses of the GET requests to be cached when HYPERFABRIC_CACHE_GET_REQUESTS is true, got %d requests", got)
+	}
+}
+
+func TestAccProviderBatchCreates(t *testing.T) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		t.Skip("Acceptance test of the batched creations requires TF_ACC_HYPERFABRIC_FAKE_API to be set")
+	}
+	fabricName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create VNIs in parallel with a provider batching the creations.
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Create VNIs in parallel with a provider batching the creations.")
+				},
+				Config:             testProviderBatchCreatesHclConfig(fabricName),
+				ExpectNonEmptyPlan: false,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hyperfabric_vni.test.0", "name", "vni0"),
+					resource.TestCheckResourceAttr("hyperfabric_vni.test.7", "name", "vni7"),
+					resource.TestCheckResourceAttrSet("hyperfabric_vni.test.0", "vni_id"),
+					resource.TestCheckResourceAttrSet("hyperfabric_vni.test.7", "vni_id"),
+					testCheckDistinctAttributes("hyperfabric_vni.test", 8, "vni_id"),
+					testCheckBatchedCreation("/vnis"),
+				),
+			},
+		},
+	})
+}
+
+// testCheckDistinctAttributes checks that the attribute of the count instances of the resource are distinct.
+func testCheckDistinctAttributes(resourceName string, count int, attribute string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		values := map[string]string{}
+		for i := 0; i < count; i++ {
+			instance := fmt.Sprintf("%s.%d", resourceName, i)
+			rs, ok := s.RootModule().Resources[instance]
+			if !ok {
+				return fmt.Errorf("resource %s not found", instance)
+			}
+			value := rs.Primary.Attributes[attribute]
+			if other, ok := values[value]; ok {
+				return fmt.Errorf("%s and %s have the same %s %s", other, instance, attribute, value)
+			}
+			values[value] = instance
+		}
+		return nil
+	}
+}
+
+// testCheckBatchedCreation checks that the fake Hyperfabric API received a POST request creating more than one object
+// in a collection of which the path ends with collection.
+func testCheckBatchedCreation(collection string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, request := range testAccFakeAPI.RequestLog() {
+			if request.Method == http.MethodPost && strings.HasSuffix(request.Path, collection) && request.Objects > 1 {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected a POST request creating several objects in %s", collection)
+	}
+}
+
+func testProviderBatchCreatesHclConfig(fabricName string) string {
+	return fmt.Sprintf(`
+provider "hyperfabric" {
+  batch_creates = true
+}
+
+resource "hyperfabric_fabric" "test" {
+  name = "%[1]s"
+}
+
+resource "hyperfabric_vni" "test" {
+  count     = 8
+  fabric_id = hyperfabric_fabric.test.id
+  name      = "vni${count.index}"
+}
+`, fabricName)
+}
+
+func TestAccProviderAutoCommit(t *testing.T) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		t.Skip("Acceptance test of the auto-commit of the changes requires TF_ACC_HYPERFABRIC_FAKE_API to be set")
+	}
+	// The resource of each test is created, updated through its description when updatable and deleted when
+	// deletable, next to a fabric with two nodes and a VRF. Each change must be committed.
+	tests := []struct {
+		resourceType string
+		config       string
+		update       bool
+		delete       bool
+	}{
+		{resourceType: "hyperfabric_node", update: true, delete: true, config: `
+  fabric_id  = hyperfabric_fabric.test.id
+  name       = "node3"
+  model_name = "HF6100-32D"
+  roles      = ["LEAF"]`},
+		{resourceType: "hyperfabric_vni", update: true, delete: true, config: `
+  fabric_id = hyperfabric_fabric.test.id
+  name      = "vni1"`},
+		{resourceType: "hyperfabric_vrf", update: true, delete: true, config: `
+  fabric_id = hyperfabric_fabric.test.id
+  name      = "Vrf2"`},
+		{resourceType: "hyperfabric_node_port", update: true, delete: true, config: `
+  node_id = hyperfabric_node.node1.id
+  name    = "Ethernet1_1"
+  roles   = ["ROUTED_PORT"]
+  vrf_id  = hyperfabric_vrf.test.vrf_id`},
+		{resourceType: "hyperfabric_node_loopback", update: true, delete: true, config: `
+  node_id      = hyperfabric_node.node1.id
+  name         = "Loopback1"
+  ipv4_address = "10.1.0.1"`},
+		{resourceType: "hyperfabric_node_sub_interface", update: true, delete: true, config: `
+  node_id = hyperfabric_node.node1.id
+  name    = "Ethernet1_1.100"`},
+		{resourceType: "hyperfabric_node_management_port", update: true, config: `
+  node_id = hyperfabric_node.node1.id`},
+		{resourceType: "hyperfabric_connection", update: true, delete: true, config: `
+  fabric_id = hyperfabric_fabric.test.id
+  local = {
+    node_id   = hyperfabric_node.node1.node_id
+    port_name = "Ethernet1_2"
+  }
+  remote = {
+    node_id   = hyperfabric_node.node2.node_id
+    port_name = "Ethernet1_2"
+  }`},
+		{resourceType: "hyperfabric_bind_to_node", delete: true, config: fmt.Sprintf(`
+  node_id   = hyperfabric_node.node1.id
+  device_id = "%s"`, fakeapi.DefaultDevices[0].DeviceId)},
+	}
+	for _, test := range tests {
+		t.Run(test.resourceType, func(t *testing.T) {
+			fabricName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+			commits := 0
+			resourceConfig := func(description string) string {
+				if !test.update {
+					return test.config
+				}
+				return fmt.Sprintf("%s\n  description = %q", test.config, description)
+			}
+			steps := []resource.TestStep{
+				{
+					PreConfig: func() {
+						fmt.Printf("= RUNNING: Provider - Commit the creation of a fabric with two nodes and a VRF before the changes of %s.\n", test.resourceType)
+					},
+					Config: testProviderAutoCommitHclConfig(fabricName, "", ""),
+					Check:  testCheckAutoCommits(fabricName, &commits),
+				},
+				{
+					PreConfig: func() {
+						fmt.Printf("= RUNNING: Provider - Commit the creation of %s.\n", test.resourceType)
+					},
+					Config: testProviderAutoCommitHclConfig(fabricName, test.resourceType, resourceConfig("Created by Terraform")),
+					Check:  testCheckAutoCommits(fabricName, &commits),
+				},
+			}
+			if test.update {
+				steps = append(steps, resource.TestStep{
+					PreConfig: func() {
+						fmt.Printf("= RUNNING: Provider - Commit the update of %s.\n", test.resourceType)
+					},
+					Config: testProviderAutoCommitHclConfig(fabricName, test.resourceType, resourceConfig("Updated by Terraform")),
+					Check:  testCheckAutoCommits(fabricName, &commits),
+				})
+			}
+			if test.delete {
+				steps = append(steps, resource.TestStep{
+					PreConfig: func() {
+						fmt.Printf("= RUNNING: Provider - Commit the deletion of %s.\n", test.resourceType)
+					},
+					Config: testProviderAutoCommitHclConfig(fabricName, "", ""),
+					Check:  testCheckAutoCommits(fabricName, &commits),
+				})
+			}
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps:                    steps,
+			})
+		})
+	}
+}
+
+// testCheckAutoCommits commits the changes of the provider instances with auto_commit set, as the provider does at
+// exit, and checks that the fake Hyperfabric API received a new auto-commit of the fabric since the previous check.
+func testCheckAutoCommits(fabricName string, commits *int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		DoAutoCommit()
+		fabricCommits := testAccFakeAPI.Commits(fabricName)
+		if len(fabricCommits) <= *commits {
+			return fmt.Errorf("expected a new commit of fabric %s, got %+v", fabricName, fabricCommits)
+		}
+		*commits = len(fabricCommits)
+		if comments := fabricCommits[len(fabricCommits)-1].Comments; comments != "Terraform Auto-Commit" {
+			return fmt.Errorf("expected an auto-commit of fabric %s, got the comments %q", fabricName, comments)
+		}
+		return nil
+	}
+}
+
+func testProviderAutoCommitHclConfig(fabricName, resourceType, config string) string {
+	resourceConfig := ""
+	if resourceType != "" {
+		resourceConfig = fmt.Sprintf("resource %q \"committed\" {%s\n}\n", resourceType, config)
+	}
+	return fmt.Sprintf(`
+provider "hyperfabric" {
+  auto_commit = true
+}
+
+resource "hyperfabric_fabric" "test" {
+  name = "%[1]s"
+}
+
+resource "hyperfabric_node" "node1" {
+  fabric_id  = hyperfabric_fabric.test.id
+  name       = "node1"
+  model_name = "HF6100-32D"
+  roles      = ["LEAF"]
+}
+
+resource "hyperfabric_node" "node2" {
+  fabric_id  = hyperfabric_fabric.test.id
+  name       = "node2"
+  model_name = "HF6100-32D"
+  roles      = ["LEAF"]
+}
+
+resource "hyperfabric_vrf" "test" {
+  fabric_id = hyperfabric_fabric.test.id
+  name      = "Vrf1"
+}
+
+%[2]s`, fabricName, resourceConfig)
+}
+
+func TestConfigureCandidate(t *testing.T) {
+	configure := func(attributes map[string]tftypes.Value) *HyperfabricProvider {
+		attributes["url"] = tftypes.NewValue(tftypes.String, "https://hyperfabric.example.com")
+		attributes["token"] = tftypes.NewValue(tftypes.String, "token")
+		p := New("test")().(*HyperfabricProvider)
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: newProviderConfig(t, attributes)}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		return p
+	}
+
+	if candidate := configure(map[string]tftypes.Value{}).client.GetCandidate(); candidate != client.DefaultCandidate {
+		t.Errorf("expected the %s candidate configuration by default, got %s", client.DefaultCandidate, candidate)
+	}
+	t.Setenv("HYPERFABRIC_CANDIDATE", "pipeline")
+	if candidate := configure(map[string]tftypes.Value{}).client.GetCandidate(); candidate != "pipeline" {
+		t.Errorf("expected the candidate configuration of HYPERFABRIC_CANDIDATE, got %s", candidate)
+	}
+	p := configure(map[string]tftypes.Value{"candidate": tftypes.NewValue(tftypes.String, "hotfix")})
+	if candidate := p.client.GetCandidate(); candidate != "hotfix" {
+		t.Errorf("expected the candidate configuration of the candidate attribute, got %s", candidate)
+	}
+}
+
+func TestAccProviderCandidate(t *testing.T) {
+	if os.Getenv("TF_ACC_HYPERFABRIC_FAKE_API") == "" {
+		t.Skip("Acceptance test of the candidate configurations requires TF_ACC_HYPERFABRIC_FAKE_API to be set")
+	}
+	fabricName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					fmt.Println("= RUNNING: Provider - Commit the candidate configuration of the provider.")
+				},
+				Config: testProviderCandidateHclConfig(fabricName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hyperfabric_vni.test", "name", "vni1"),
+					resource.TestCheckResourceAttr("hyperfabric_vrf.test", "name", "Vrf1"),
+					testCheckCandidateCommits(fabricName, []string{"pipeline"}),
+				),
+			},
+		},
+	})
+}
+
+// testCheckCandidateCommits checks that the auto-commit commits the candidate configurations of the fabric.
+func testCheckCandidateCommits(fabricName string, candidates []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		DoAutoCommit()
+		committed := []string{}
+		for _, commit := range testAccFakeAPI.Commits(fabricName) {
+			committed = append(committed, commit.Candidate)
+		}
+		sort.Strings(committed)
+		if !reflect.DeepEqual(committed, candidates) {
+			return fmt.Errorf("expected the commits of the candidate configurations %q of fabric %s, got %q", candidates, fabricName, committed)
+		}
+		return nil
+	}
+}
+
+func testProviderCandidateHclConfig(fabricName string) string {
+	return fmt.Sprintf(`
+provider "hyperfabric" {
+  auto_commit = true
+  candidate   = "pipeline"
+}
+
+resource "hyperfabric_fabric" "test" {
+  name = "%[1]s"
+}
+
+resource "hyperfabric_vni" "test" {
+  fabric_id = hyperfabric_fabric.test.id
+  name      = "vni1"
+}
+
+resource "hyperfabric_vrf" "test" {
+  fabric_id = hyperfabric_fabric.test.id
+  name      = "Vrf1"
+}
+`, fabricName)
+}
@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+)
+
+// statsFile is the file of the statistics of this process, created in the directory of HYPERFABRIC_STATS_FILE.
+var statsFile struct {
+	sync.Mutex
+	// setting is the value of HYPERFABRIC_STATS_FILE the file was created for.
+	setting string
+	path    string
+}
+
+// ReportStats logs a summary of the usage of the Hyperfabric API by every provider instance configured in this
+// process, and writes it to its statistics file, see WriteStats.
+// Nothing is reported when no provider instance was configured, such as when Terraform only read the schema.
+func ReportStats() error {
+	clients := getConfiguredClients()
+	for _, c := range clients {
+		clientStats := c.Stats()
+		log.Printf("[INFO] Hyperfabric API usage of %s: %d requests, %d retries, %d connection errors, %s of backoff%s.",
+			clientStats.URL, clientStats.Requests, clientStats.Retries, clientStats.ConnectionErrors,
+			secondsToDuration(clientStats.BackoffSeconds), formatStatusCodes(clientStats.StatusCodes))
+		for _, endpoint := range clientStats.Endpoints {
+			average := endpoint.Latency.SumSeconds / float64(endpoint.Latency.Count)
+			log.Printf("[INFO]   %s %s: %d requests, %d retries, %d connection errors, %s average, %s maximum%s.",
+				endpoint.Method, endpoint.Path, endpoint.Requests, endpoint.Retries, endpoint.ConnectionErrors,
+				secondsToDuration(average), secondsToDuration(endpoint.Latency.MaxSeconds), formatStatusCodes(endpoint.StatusCodes))
+		}
+	}
+	return WriteStats()
+}
+
+// WriteStats writes the usage of the Hyperfabric API by every provider instance configured in this process as a line
+// of JSON when the HYPERFABRIC_STATS_FILE environment variable is set. Terraform can run the provider several times in
+// a command, so every process has its own file next to the path of HYPERFABRIC_STATS_FILE, such as stats.123456.json
+// for stats.json, which is replaced with the latest statistics on every call. It is called when no operation is in
+// progress, since Terraform may kill the provider before it returns from serving.
+func WriteStats() error {
+	setting := os.Getenv("HYPERFABRIC_STATS_FILE")
+	clients := getConfiguredClients()
+	if setting == "" || len(clients) == 0 {
+		return nil
+	}
+	stats := make([]client.Stats, 0, len(clients))
+	for _, c := range clients {
+		stats = append(stats, c.Stats())
+	}
+	content, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to encode the Hyperfabric API usage: %w", err)
+	}
+
+	statsFile.Lock()
+	defer statsFile.Unlock()
+	dir := filepath.Dir(setting)
+	if statsFile.setting != setting {
+		extension := filepath.Ext(setting)
+		file, err := os.CreateTemp(dir, strings.TrimSuffix(filepath.Base(setting), extension)+".*"+extension)
+		if err != nil {
+			return fmt.Errorf("failed to create the Hyperfabric API usage file: %w", err)
+		}
+		file.Close()
+		statsFile.setting = setting
+		statsFile.path = file.Name()
+	}
+	// The file is replaced at once, so it is never read partially written.
+	file, err := os.CreateTemp(dir, "."+filepath.Base(statsFile.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write the Hyperfabric API usage: %w", err)
+	}
+	_, err = file.Write(append(content, '\n'))
+	if err == nil {
+		err = file.Chmod(0o644)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), statsFile.path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("failed to write the Hyperfabric API usage: %w", err)
+	}
+	return nil
+}
+
+// formatStatusCodes returns the counts of statusCodes by status code, such as ", status codes 200: 12, 404: 1".
+func formatStatusCodes(statusCodes map[string]int) string {
+	if len(statusCodes) == 0 {
+		return ""
+	}
+	codes := make([]string, 0, len(statusCodes))
+	for code := range statusCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	counts := make([]string, len(codes))
+	for i, code := range codes {
+		counts[i] = fmt.Sprintf("%s: %d", code, statusCodes[code])
+	}
+	return ", status codes " + strings.Join(counts, ", ")
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Millisecond)
+}
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestReportStats(t *testing.T) {
+	server := fakeapi.NewServer()
+	defer server.Close()
+	c := client.NewClient(server.URL, server.Token, client.Insecure(true))
+	configuredClients.Lock()
+	configuredClients.clients = append(configuredClients.clients, c)
+	configuredClients.Unlock()
+
+	if _, err := c.Fabrics.Create(context.Background(), &client.Fabric{Name: client.String("fabric1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("HYPERFABRIC_STATS_FILE", filepath.Join(t.TempDir(), "stats.json"))
+	// The statistics are written when the operations are done, Terraform may kill the provider before it exits.
+	var diags diag.Diagnostics
+	_, span := startSpan(context.Background(), "hyperfabric_fabric.Create")
+	endSpan(span, &diags)
+	statsDir := filepath.Dir(os.Getenv("HYPERFABRIC_STATS_FILE"))
+	if files, _ := filepath.Glob(filepath.Join(statsDir, "stats.*.json")); len(files) != 1 {
+		t.Fatalf("expected the statistics to be written when the operations are done, got %v", files)
+	}
+	if err := ReportStats(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Terraform runs the provider several times in a command, each run has its own file replaced on every write.
+	files, err := filepath.Glob(filepath.Join(statsDir, "*"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 1 || !strings.HasPrefix(filepath.Base(files[0]), "stats.") || filepath.Ext(files[0]) != ".json" {
+		t.Fatalf("expected a statistics file for the run, got %v", files)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected a line of statistics, got %s", content)
+	}
+	var stats []client.Stats
+	if err := json.Unmarshal([]byte(lines[0]), &stats); err != nil {
+		t.Fatalf("the statistics line is not valid JSON: %v", err)
+	}
+	for _, clientStats := range stats {
+		if clientStats.URL != server.URL {
+			continue
+		}
+		if clientStats.Requests != 1 || len(clientStats.Endpoints) != 1 || clientStats.Endpoints[0].Path != "/api/v1/fabrics" || clientStats.Endpoints[0].StatusCodes["200"] != 1 {
+			t.Errorf("unexpected statistics: %s", content)
+		}
+		return
+	}
+	t.Errorf("expected the statistics of the client of %s, got %s", server.URL, content)
+}
@@ -9,6 +9,7 @@ package provider
 import (
 	"context"
 
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -124,39 +125,25 @@ func getSviDataSourceSchemaAttribute() schema.SingleNestedAttribute {
 	}
 }
 
-func NewSviResourceModel(ctx context.Context, data map[string]interface{}) SviResourceModel {
+func NewSviResourceModel(ctx context.Context, data client.Svi) SviResourceModel {
 	svi := getEmptySviResourceModel()
 	svi.Enabled = basetypes.NewBoolValue(false)
-	for attributeName, attributeValue := range data {
-		if attributeName == "enabled" && attributeValue != nil {
-			boolAttr := attributeValue.(bool)
-			svi.Enabled = basetypes.NewBoolValue(boolAttr)
-		} else if attributeName == "ipv4Addresses" && attributeValue != nil {
-			stringListAttr := attributeValue.([]interface{})
-			if len(stringListAttr) > 0 {
-				ipv4AddressesSet, _ := types.SetValueFrom(ctx, types.StringType, stringListAttr)
-				svi.Ipv4Addresses = ipv4AddressesSet
-			}
-		} else if attributeName == "ipv6Addresses" && attributeValue != nil {
-			stringListAttr := attributeValue.([]interface{})
-			if len(stringListAttr) > 0 {
-				ipv6AddressesSet, _ := types.SetValueFrom(ctx, types.StringType, stringListAttr)
-				svi.Ipv6Addresses = ipv6AddressesSet
-			}
-			// } else if attributeName == "vlanId" && attributeValue != nil {
-			// 	int32Attr := attributeValue.(int32)
-			// 	if int32Attr != 0 {
-			// 		svi.VlanId = basetypes.NewInt32Value(int32Attr)
-			// 	}
-		}
+	setBoolValue(&svi.Enabled, data.Enabled)
+	if len(data.Ipv4Addresses) > 0 {
+		ipv4AddressesSet, _ := types.SetValueFrom(ctx, types.StringType, data.Ipv4Addresses)
+		svi.Ipv4Addresses = ipv4AddressesSet
+	}
+	if len(data.Ipv6Addresses) > 0 {
+		ipv6AddressesSet, _ := types.SetValueFrom(ctx, types.StringType, data.Ipv6Addresses)
+		svi.Ipv6Addresses = ipv6AddressesSet
 	}
 	return svi
 }
 
-func NewSviObject(ctx context.Context, data []interface{}) basetypes.ObjectValue {
+func NewSviObject(ctx context.Context, data []client.Svi) basetypes.ObjectValue {
 	var sviObject basetypes.ObjectValue
 	if len(data) > 0 {
-		svi := NewSviResourceModel(ctx, data[0].(map[string]interface{}))
+		svi := NewSviResourceModel(ctx, data[0])
 		sviObject, _ = types.ObjectValueFrom(ctx, SviResourceModelAttributeType(), svi)
 	} else {
 		sviObject = basetypes.NewObjectNull(SviResourceModelAttributeType())
@@ -164,20 +151,17 @@ func NewSviObject(ctx context.Context, data []interface{}) basetypes.ObjectValue
 	return sviObject
 }
 
-func getSviJsonPayload(ctx context.Context, data basetypes.ObjectValue) []map[string]interface{} {
+func getSviJsonPayload(ctx context.Context, data basetypes.ObjectValue) []client.Svi {
 	svi := SviResourceModel{}
 	data.As(ctx, &svi, basetypes.ObjectAsOptions{})
 	ipv4Addresses := make([]string, 0)
 	ipv6Addresses := make([]string, 0)
 	svi.Ipv4Addresses.ElementsAs(ctx, &ipv4Addresses, false)
 	svi.Ipv6Addresses.ElementsAs(ctx, &ipv6Addresses, false)
-	sviPayload := map[string]interface{}{
-		"enabled":       svi.Enabled.ValueBool(),
-		"ipv4Addresses": ipv4Addresses,
-		"ipv6Addresses": ipv6Addresses,
-		// "vlanId":        svi.VlanId,
+	sviPayload := client.Svi{
+		Enabled:       client.Bool(svi.Enabled.ValueBool()),
+		Ipv4Addresses: ipv4Addresses,
+		Ipv6Addresses: ipv6Addresses,
 	}
-	svisPayloads := make([]map[string]interface{}, 0)
-	svisPayloads = append(svisPayloads, sviPayload)
-	return svisPayloads
+	return []client.Svi{sviPayload}
 }
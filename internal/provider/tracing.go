@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the OpenTelemetry tracer of the spans of the operations of the resources and data sources.
+const tracerName = "github.com/cisco-open/terraform-provider-hyperfabric/internal/provider"
+
+// flushTimeout bounds the export of the spans when the operations of the resources and data sources are done, and
+// when the provider stops, since Terraform kills the provider 2 seconds after asking it to stop.
+const flushTimeout = time.Second
+
+// tracerProvider is the tracer provider of StartTracing, or nil when tracing is not enabled.
+var tracerProvider *sdktrace.TracerProvider
+
+// operations counts the operations of the resources and data sources in progress, see startSpan and endSpan.
+var operations struct {
+	sync.Mutex
+	inProgress int
+}
+
+// StartTracing exports the spans of the operations of the resources and data sources, and of the requests sent to
+// the Hyperfabric service, with OTLP over HTTP. Tracing is enabled by the OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variable, and configured by the other standard environment
+// variables of OpenTelemetry, such as OTEL_EXPORTER_OTLP_HEADERS or OTEL_SERVICE_NAME. The spans are exported once
+// no operation is in progress, see endSpan. The returned function flushes the spans not exported yet and stops the
+// export, it is a no-op when tracing is not enabled.
+func StartTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return noop, nil
+	}
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return noop, nil
+	}
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	if protocol != "" && protocol != "http/protobuf" {
+		return noop, fmt.Errorf("the OTLP protocol %s is not supported, only http/protobuf is supported", protocol)
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence over the default service attributes
+	tracingResource, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "terraform-provider-hyperfabric"),
+			attribute.String("service.version", version),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create the OpenTelemetry resource: %w", err)
+	}
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(flushTimeout), sdktrace.WithExportTimeout(flushTimeout)),
+		sdktrace.WithResource(tracingResource),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tracerProvider.Shutdown, nil
+}
+
+// startSpan starts the span of an operation of a resource or data source, such as hyperfabric_node.Create. The spans
+// of the requests sent to the Hyperfabric service with the returned context are its children.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	operations.Lock()
+	operations.inProgress++
+	operations.Unlock()
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
+
+// endSpan records the first error of diags, if any, on span and ends it. The statistics are written and the spans not
+// exported yet are flushed when no other operation is in progress, since Terraform may stop the provider once it has
+// no operation left.
+func endSpan(span trace.Span, diags *diag.Diagnostics) {
+	if errors := diags.Errors(); len(errors) > 0 {
+		span.SetStatus(codes.Error, errors[0].Summary())
+	}
+	span.End()
+	operations.Lock()
+	operations.inProgress--
+	idle := operations.inProgress == 0
+	operations.Unlock()
+	if idle {
+		operationsDone()
+	}
+}
+
+// operationsDone writes the statistics and flushes the spans not exported yet once no operation is in progress.
+func operationsDone() {
+	if err := WriteStats(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+	if tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := tracerProvider.ForceFlush(ctx); err != nil {
+		log.Printf("[WARN] Export of the traces failed: %s", err)
+	}
+}
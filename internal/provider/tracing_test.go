@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace/noop"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// traceCollector is a stand-in for an OpenTelemetry collector receiving spans with OTLP over HTTP.
+type traceCollector struct {
+	*httptest.Server
+	lock  sync.Mutex
+	spans map[string]*tracepb.Span
+	// services are the service.name attributes of the resources of the spans.
+	services []string
+}
+
+func newTraceCollector(t *testing.T) *traceCollector {
+	collector := &traceCollector{spans: map[string]*tracepb.Span{}}
+	collector.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request collectortrace.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		collector.lock.Lock()
+		defer collector.lock.Unlock()
+		for _, resourceSpans := range request.ResourceSpans {
+			for _, kv := range resourceSpans.Resource.Attributes {
+				if kv.Key == "service.name" {
+					collector.services = append(collector.services, kv.Value.GetStringValue())
+				}
+			}
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					collector.spans[span.Name] = span
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		response, _ := proto.Marshal(&collectortrace.ExportTraceServiceResponse{})
+		w.Write(response)
+	}))
+	t.Cleanup(collector.Close)
+	return collector
+}
+
+func TestStartTracingDisabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	shutdown, err := StartTracing(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	if _, err := StartTracing(context.Background(), "test"); err == nil {
+		t.Errorf("expected an error for the unsupported protocol")
+	}
+}
+
+func TestStartTracing(t *testing.T) {
+	collector := newTraceCollector(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	t.Cleanup(func() {
+		tracerProvider = nil
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	shutdown, err := StartTracing(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer shutdown(context.Background())
+
+	server := fakeapi.NewServer()
+	defer server.Close()
+	var traceparent string
+	serverHandler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		serverHandler.ServeHTTP(w, r)
+	})
+	c := client.NewClient(server.URL, server.Token, client.Insecure(true))
+
+	var diags diag.Diagnostics
+	ctx, span := startSpan(context.Background(), "hyperfabric_fabric.Create")
+	if _, err := c.Fabrics.Create(ctx, &client.Fabric{Name: client.String("fabric1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The spans are exported once the last operation in progress ends, without waiting for the provider to stop.
+	endSpan(span, &diags)
+
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	operation, request := collector.spans["hyperfabric_fabric.Create"], collector.spans["POST /api/v1/fabrics"]
+	if operation == nil || request == nil {
+		t.Fatalf("expected the spans of the operation and of its request, got %v", collector.spans)
+	}
+	if string(request.ParentSpanId) != string(operation.SpanId) || string(request.TraceId) != string(operation.TraceId) {
+		t.Errorf("expected the span of the request to be a child of the span of the operation")
+	}
+	if request.Kind != tracepb.Span_SPAN_KIND_CLIENT {
+		t.Errorf("expected the span of the request to be a client span, got %s", request.Kind)
+	}
+	if traceparent == "" {
+		t.Errorf("expected the trace context to be propagated to the Hyperfabric service")
+	}
+	if len(collector.services) == 0 || collector.services[0] != "terraform-provider-hyperfabric" {
+		t.Errorf("unexpected service name of the spans: %v", collector.services)
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -97,6 +97,8 @@ func (d *UserDataSource) Configure(ctx context.Context, req datasource.Configure
 
 func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_user")
+	ctx, span := startSpan(ctx, "data.hyperfabric_user.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *UserResourceModel
 
 	// Read Terraform configuration data into the model
@@ -8,11 +8,9 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -32,6 +30,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -110,6 +109,10 @@ type UserIdentifier struct {
 	Id types.String
 }
 
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_user")
 	resp.TypeName = req.ProviderTypeName + "_user"
@@ -204,6 +207,8 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_user")
+	ctx, span := startSpan(ctx, "hyperfabric_user.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *UserResourceModel
 
@@ -215,24 +220,16 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_user with email '%s'", data.Email.ValueString()))
 
-	jsonPayload := getUserJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, "/api/v1/users", "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	userContainer, err := container.ArrayElement(0, "users")
+	user := getUserPayload(ctx, data)
+	user.Email = getStringPayload(data.Email)
+	user, err := r.client.Users.Create(ctx, user)
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
 
-	userId := StripQuotes(userContainer.Search("id").String())
-	if userId != "" {
-		data.Id = basetypes.NewStringValue(userId)
+	if user != nil && user.Id != "" {
+		data.Id = basetypes.NewStringValue(user.Id)
 		getAndSetUserAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -245,6 +242,8 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_user")
+	ctx, span := startSpan(ctx, "hyperfabric_user.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *UserResourceModel
 
 	// Read Terraform prior state data into the model
@@ -271,6 +270,8 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_user")
+	ctx, span := startSpan(ctx, "hyperfabric_user.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *UserResourceModel
 	var stateData *UserResourceModel
 
@@ -284,15 +285,9 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_user with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getUserJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/users/%s", data.Id.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	_, err := r.client.Users.Update(withRevision(ctx, stateData.Metadata), data.Id.ValueString(), getUserPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
 
@@ -305,6 +300,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_user")
+	ctx, span := startSpan(ctx, "hyperfabric_user.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *UserResourceModel
 
 	// Read Terraform prior state data into the model
@@ -315,8 +312,9 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_user with id '%s'", data.Id.ValueString()))
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/users/%s", data.Id.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	err := r.client.Users.Delete(withRevision(ctx, data.Metadata), data.Id.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_user with id '%s'", data.Id.ValueString()))
@@ -332,93 +330,36 @@ func (r *UserResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 func getAndSetUserAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *UserResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/users/%s", data.Id.ValueString()), "GET", nil)
-	// requestData := DoRestRequest(ctx, diags, client, "/api/v1/users", "GET", nil)
-	if diags.HasError() {
+	user, err := client.Users.Get(ctx, data.Id.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newUser := *getNewUserResourceModelFromData(data)
-	// newUser.Id = data.Id
-	// newUser.Email = data.Email
-
-	if requestData.Data() != nil {
-		attributes := requestData.Data().(map[string]interface{})
-		for attributeName, attributeValue := range attributes {
-			if attributeName == "id" && (data.Id.IsNull() || data.Id.IsUnknown() || data.Id.ValueString() == "" || data.Id.ValueString() != attributeValue.(string)) {
-				newUser.Id = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "email" {
-				newUser.Email = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "provider" {
-				newUser.Provider = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "lastLogin" {
-				newUser.LastLogin = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "enabled" {
-				newUser.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "role" {
-				newUser.Role = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "metadata" {
-				newUser.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newUser.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-				// } else if attributeName == "annotations" {
-				// 	newUser.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+
+	if user != nil {
+		if user.Id != "" && user.Id != data.Id.ValueString() {
+			newUser.Id = basetypes.NewStringValue(user.Id)
 		}
+		setStringValue(&newUser.Email, user.Email)
+		setStringValue(&newUser.Provider, user.Provider)
+		setStringValue(&newUser.LastLogin, user.LastLogin)
+		setBoolValue(&newUser.Enabled, user.Enabled)
+		setStringValue(&newUser.Role, user.Role)
+		setMetadataValue(ctx, &newUser.Metadata, user.Metadata)
+		setSetStringValue(ctx, &newUser.Labels, user.Labels)
 	} else {
 		newUser.Id = basetypes.NewStringNull()
 	}
 	*data = newUser
 }
 
-func getUserJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *UserResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Email.IsNull() && !data.Email.IsUnknown() && action == "create" {
-		payloadMap["email"] = data.Email.ValueString()
-	}
-
-	if !data.Role.IsNull() && !data.Role.IsUnknown() {
-		payloadMap["role"] = data.Role.ValueString()
-	}
-
-	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-		payloadMap["enabled"] = data.Enabled.ValueBool()
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	}
-
-	// if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-	// 	payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	// }
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"users": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+// getUserPayload returns the updatable attributes of the user, the email can only be set on create.
+func getUserPayload(ctx context.Context, data *UserResourceModel) *client.User {
+	return &client.User{
+		Role:    getStringPayload(data.Role),
+		Enabled: getBoolPayload(data.Enabled),
+		Labels:  getSetStringPayload(ctx, data.Labels),
 	}
-	return jsonPayload
 }
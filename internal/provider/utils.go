@@ -8,13 +8,16 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -70,13 +73,178 @@ func G(cont *gabs.Container, key string) string {
 	return StripQuotes(cont.S(key).String())
 }
 
-func DoRestRequest(ctx context.Context, diags *diag.Diagnostics, restClient *client.Client, path, method string, payload *gabs.Container) *gabs.Container {
-	container, err := restClient.DoRestRequest(path, method, payload)
-	if err != nil {
-		diags.AddError(err.Summary, err.Detail)
+// AddClientError adds an error returned by the typed services of the client package to diags.
+func AddClientError(diags *diag.Diagnostics, err error) {
+	var diagError *client.DiagError
+	if client.IsRevisionMismatch(err) {
+		diags.AddError(
+			"Conflicting change in the Hyperfabric service",
+			fmt.Sprintf("The object was modified outside of Terraform since it was last read and the change was rejected to not overwrite the modification. Run terraform again to review the changes against the current object, or set retry_on_conflict in the provider configuration to retry automatically with the current object.\n\n%s", err),
+		)
+		return
+	}
+	if errors.As(err, &diagError) {
+		diags.AddError(diagError.Summary, diagError.Detail)
+		return
+	}
+	diags.AddError(
+		"Hyperfabric API request failed",
+		fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
+	)
+}
+
+// withRevision returns a context making the update or delete of an object conditional on the revision of the
+// object in metadata, so that a modification made outside of Terraform since the last read is not overwritten.
+func withRevision(ctx context.Context, metadata types.Object) context.Context {
+	if metadata.IsNull() || metadata.IsUnknown() {
+		return ctx
+	}
+	revisionId, ok := metadata.Attributes()["revision_id"].(types.String)
+	if !ok || revisionId.IsNull() || revisionId.IsUnknown() {
+		return ctx
+	}
+	return client.WithRevision(ctx, revisionId.ValueString())
+}
+
+// attributeSchema is implemented by the schemas of the plan, state and config, see AddClientAttributeError.
+type attributeSchema interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// AddClientAttributeError adds an error returned by the typed services of the client package to diags.
+// The error is added to the attribute of schema matching the field reported by the Hyperfabric service, if any.
+func AddClientAttributeError(ctx context.Context, diags *diag.Diagnostics, schema attributeSchema, err error) {
+	var diagError *client.DiagError
+	var apiError *client.APIError
+	if errors.As(err, &diagError) && errors.As(err, &apiError) && apiError.RestError.Field != "" && !client.IsRevisionMismatch(err) {
+		if attributePath, ok := getAttributePathFromField(ctx, schema, apiError.RestError.Field); ok {
+			diags.AddAttributeError(attributePath, diagError.Summary, diagError.Detail)
+			return
+		}
+	}
+	AddClientError(diags, err)
+}
+
+// apiFieldAttributeNames holds the attribute names not derived from the API field name, an empty name has no attribute.
+var apiFieldAttributeNames = map[string]string{
+	"id":       "",
+	"provider": "auth_provider",
+	"svis":     "svi",
+}
+
+// getAttributePathFromField returns the path of the attribute of schema matching a field of the API, such as
+// "vnis[0].members[1].vlanId". Indexes are dropped, since they do not match the elements of sets, and the leading
+// fields without an attribute, such as the envelope of a create request, are skipped.
+func getAttributePathFromField(ctx context.Context, schema attributeSchema, field string) (path.Path, bool) {
+	var attributeNames []string
+	for _, fieldName := range strings.Split(field, ".") {
+		fieldName, _, _ = strings.Cut(fieldName, "[")
+		attributeName, ok := apiFieldAttributeNames[fieldName]
+		if !ok {
+			attributeName = toSnakeCase(fieldName)
+		}
+		attributeNames = append(attributeNames, attributeName)
+	}
+	for start := range attributeNames {
+		for end := len(attributeNames); end > start; end-- {
+			if attributeNames[start] == "" {
+				break
+			}
+			attributePath := path.Root(attributeNames[start])
+			for _, attributeName := range attributeNames[start+1 : end] {
+				attributePath = attributePath.AtName(attributeName)
+			}
+			if _, typeDiags := schema.TypeAtPath(ctx, attributePath); !typeDiags.HasError() {
+				return attributePath, true
+			}
+		}
+	}
+	return path.Empty(), false
+}
+
+func toSnakeCase(name string) string {
+	var snakeCase strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				snakeCase.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		snakeCase.WriteRune(r)
+	}
+	return snakeCase.String()
+}
+
+// Helpers converting between attribute values and the optional fields of the client API types.
+// A field absent from a response leaves the attribute unchanged and a null or unknown attribute is not sent.
+
+func setStringValue(attribute *basetypes.StringValue, value *string) {
+	if value != nil {
+		*attribute = basetypes.NewStringValue(*value)
+	}
+}
+
+func setBoolValue(attribute *basetypes.BoolValue, value *bool) {
+	if value != nil {
+		*attribute = basetypes.NewBoolValue(*value)
+	}
+}
+
+func setFloat64Value(attribute *basetypes.Float64Value, value *float64) {
+	if value != nil {
+		*attribute = basetypes.NewFloat64Value(*value)
+	}
+}
+
+func setSetStringValue(ctx context.Context, attribute *basetypes.SetValue, value []string) {
+	if value != nil {
+		*attribute = NewSetString(ctx, value)
+	}
+}
+
+func getStringPayload(data basetypes.StringValue) *string {
+	if data.IsNull() || data.IsUnknown() {
+		return nil
+	}
+	return client.String(data.ValueString())
+}
+
+func getBoolPayload(data basetypes.BoolValue) *bool {
+	if data.IsNull() || data.IsUnknown() {
 		return nil
 	}
-	return container
+	return client.Bool(data.ValueBool())
+}
+
+func getFloat64Payload(data basetypes.Float64Value) *float64 {
+	if data.IsNull() || data.IsUnknown() {
+		return nil
+	}
+	return client.Float64(data.ValueFloat64())
+}
+
+func getSetStringPayload(ctx context.Context, data basetypes.SetValue) []string {
+	if data.IsNull() || data.IsUnknown() {
+		return nil
+	}
+	return getSetStringJsonPayload(ctx, data)
+}
+
+// splitNodeId splits the composite Id of a node, "fabricId/nodes/nodeId", into the Ids of the fabric and the node.
+func splitNodeId(nodeId string) (string, string) {
+	fabricId, nodeId, _ := strings.Cut(nodeId, "/nodes/")
+	return fabricId, nodeId
+}
+
+// addChangedFabric registers the fabric of the composite Id of an object, such as "fabricId/vnis/vniId" or
+// "fabricId/nodes/nodeId/ports/portId", as changed so that its candidate configuration is committed when auto_commit
+// is set.
+func addChangedFabric(ctx context.Context, client *client.Client, id string) {
+	fabricId, _, _ := strings.Cut(id, "/")
+	if fabricId != "" {
+		client.AddChangedFabric(fabricId)
+	}
 }
 
 type setToStringNullWhenStateIsNullPlanIsUnknownDuringUpdate struct{}
@@ -246,7 +414,7 @@ func getSetStringJsonPayload(ctx context.Context, data basetypes.SetValue) []str
 	return strings
 }
 
-func NewSetString(ctx context.Context, data []interface{}) basetypes.SetValue {
+func NewSetString(ctx context.Context, data []string) basetypes.SetValue {
 	stringsSet, _ := types.SetValueFrom(ctx, SetStringResourceModelAttributeType(), data)
 	return stringsSet
 }
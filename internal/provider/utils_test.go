@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestGetAttributePathFromField(t *testing.T) {
+	ctx := context.Background()
+	schemaResponse := &resource.SchemaResponse{}
+	NewVniResource().Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+
+	tests := []struct {
+		field    string
+		expected path.Path
+		found    bool
+	}{
+		{"name", path.Root("name"), true},
+		{"vrfId", path.Root("vrf_id"), true},
+		{"vnis[0].mtu", path.Root("mtu"), true},
+		{"members[1].vlanId", path.Root("members"), true},
+		{"svis[0].ipv4Addresses", path.Root("svi").AtName("ipv4_addresses"), true},
+		{"id", path.Empty(), false},
+		{"unknownField", path.Empty(), false},
+	}
+	for _, test := range tests {
+		attributePath, found := getAttributePathFromField(ctx, schemaResponse.Schema, test.field)
+		if found != test.found || !attributePath.Equal(test.expected) {
+			t.Errorf("getAttributePathFromField(%q) = %s, %v, expected %s, %v", test.field, attributePath, found, test.expected, test.found)
+		}
+	}
+}
+
+func TestWithRevision(t *testing.T) {
+	ctx := context.Background()
+	metadata := NewMetadataObject(ctx, &client.Metadata{RevisionId: "7"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") != "7" {
+			t.Errorf("expected the revision of the metadata in If-Match, got %q", r.Header.Get("If-Match"))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "token", client.MaxRetries(0))
+	if err := c.Fabrics.Delete(withRevision(ctx, metadata), "f1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if withRevision(ctx, basetypes.NewObjectNull(MetadataResourceModelAttributeType())) != ctx {
+		t.Error("expected no revision without metadata")
+	}
+}
@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -30,6 +28,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &VniResource{}
 var _ resource.ResourceWithImportState = &VniResource{}
+var _ resource.ResourceWithModifyPlan = &VniResource{}
 
 func NewVniResource() resource.Resource {
 	return &VniResource{}
@@ -180,6 +179,7 @@ func (r *VniResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 		}
 
 		resp.Diagnostics.Append(resp.Plan.Set(ctx, &planData)...)
+		setMetadataUnknownWhenUpdated(ctx, req, resp)
 	}
 }
 
@@ -321,6 +321,8 @@ func (r *VniResource) Configure(ctx context.Context, req resource.ConfigureReque
 
 func (r *VniResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_vni")
+	ctx, span := startSpan(ctx, "hyperfabric_vni.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *VniResourceModel
 
@@ -332,24 +334,16 @@ func (r *VniResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_vni in Fabric '%s' with name '%s'", data.FabricId.ValueString(), data.Name.ValueString()))
 
-	jsonPayload := getVniJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/vnis", data.FabricId.ValueString()), "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	vniContainer, err := container.ArrayElement(0, "vnis")
+	vni, err := r.client.Vnis.Create(ctx, data.FabricId.ValueString(), getVniPayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
-	vniId := StripQuotes(vniContainer.Search("id").String())
-	if vniId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vnis/%s", data.FabricId.ValueString(), vniId))
-		data.VniId = basetypes.NewStringValue(vniId)
+	addChangedFabric(ctx, r.client, data.FabricId.ValueString())
+
+	if vni != nil && vni.Id != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vnis/%s", data.FabricId.ValueString(), vni.Id))
+		data.VniId = basetypes.NewStringValue(vni.Id)
 		getAndSetVniAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -362,6 +356,8 @@ func (r *VniResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 func (r *VniResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_vni")
+	ctx, span := startSpan(ctx, "hyperfabric_vni.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *VniResourceModel
 
 	// Read Terraform prior state data into the model
@@ -388,6 +384,8 @@ func (r *VniResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 func (r *VniResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_vni")
+	ctx, span := startSpan(ctx, "hyperfabric_vni.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *VniResourceModel
 	var stateData *VniResourceModel
 
@@ -401,17 +399,12 @@ func (r *VniResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_vni with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getVniJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/vnis/%s", data.FabricId.ValueString(), data.VniId.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	_, err := r.client.Vnis.Update(withRevision(ctx, stateData.Metadata), data.FabricId.ValueString(), data.VniId.ValueString(), getVniPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 
 	getAndSetVniAttributes(ctx, &resp.Diagnostics, r.client, data)
 
@@ -422,6 +415,8 @@ func (r *VniResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 func (r *VniResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_vni")
+	ctx, span := startSpan(ctx, "hyperfabric_vni.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *VniResourceModel
 
 	// Read Terraform prior state data into the model
@@ -433,10 +428,12 @@ func (r *VniResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_vni with id '%s'", data.Id.ValueString()))
 	checkAndSetVniIds(data)
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/vnis/%s", data.FabricId.ValueString(), data.VniId.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	err := r.client.Vnis.Delete(withRevision(ctx, data.Metadata), data.FabricId.ValueString(), data.VniId.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_vni with id '%s'", data.Id.ValueString()))
 }
 
@@ -450,51 +447,42 @@ func (r *VniResource) ImportState(ctx context.Context, req resource.ImportStateR
 }
 
 func getAndSetVniAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *VniResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/vnis/%s", data.FabricId.ValueString(), data.VniId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	vni, err := client.Vnis.Get(ctx, data.FabricId.ValueString(), data.VniId.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newVni := *getNewVniResourceModelFromData(data)
 
-	if requestData.Data() != nil {
-		attributes := requestData.Data().(map[string]interface{})
-		for attributeName, attributeValue := range attributes {
-			if attributeName == "fabricId" && (data.FabricId.IsNull() || data.FabricId.IsUnknown() || data.FabricId.ValueString() == "" || data.FabricId.ValueString() != attributeValue.(string)) {
-				newVni.FabricId = basetypes.NewStringValue(attributeValue.(string))
-				newVni.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vnis/%s", newVni.FabricId.ValueString(), newVni.VniId.ValueString()))
-			} else if attributeName == "id" && (data.VniId.IsNull() || data.VniId.IsUnknown() || data.VniId.ValueString() == "" || data.VniId.ValueString() != attributeValue.(string)) {
-				newVni.VniId = basetypes.NewStringValue(attributeValue.(string))
-				newVni.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vnis/%s", newVni.FabricId.ValueString(), newVni.VniId.ValueString()))
-			} else if attributeName == "name" {
-				newVni.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newVni.Description = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "enabled" {
-				newVni.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "isDefault" {
-				newVni.IsDefault = basetypes.NewBoolValue(attributeValue.(bool))
-				// } else if attributeName == "isL3" {
-				// 	newVni.IsL3 = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "vrfId" {
-				newVni.VrfId = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "vni" {
-				newVni.Vni = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "mtu" {
-				newVni.Mtu = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "members" {
-				stateMembers := make([]MemberResourceModel, 0)
-				data.Members.ElementsAs(ctx, &stateMembers, false)
-				newVni.Members = NewMembersSet(ctx, &stateMembers, attributeValue.([]interface{}))
-			} else if attributeName == "svis" {
-				newVni.Svi = NewSviObject(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "metadata" {
-				newVni.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newVni.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "annotations" {
-				newVni.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+	if vni != nil {
+		if vni.FabricId != "" && vni.FabricId != data.FabricId.ValueString() {
+			newVni.FabricId = basetypes.NewStringValue(vni.FabricId)
+			newVni.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vnis/%s", newVni.FabricId.ValueString(), newVni.VniId.ValueString()))
+		}
+		if vni.Id != "" && vni.Id != data.VniId.ValueString() {
+			newVni.VniId = basetypes.NewStringValue(vni.Id)
+			newVni.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vnis/%s", newVni.FabricId.ValueString(), newVni.VniId.ValueString()))
+		}
+		setStringValue(&newVni.Name, vni.Name)
+		setStringValue(&newVni.Description, vni.Description)
+		setBoolValue(&newVni.Enabled, vni.Enabled)
+		setBoolValue(&newVni.IsDefault, vni.IsDefault)
+		setStringValue(&newVni.VrfId, vni.VrfId)
+		setFloat64Value(&newVni.Vni, vni.Vni)
+		setFloat64Value(&newVni.Mtu, vni.Mtu)
+		if vni.Members != nil {
+			stateMembers := make([]MemberResourceModel, 0)
+			data.Members.ElementsAs(ctx, &stateMembers, false)
+			newVni.Members = NewMembersSet(ctx, &stateMembers, vni.Members)
+		}
+		if vni.Svis != nil {
+			newVni.Svi = NewSviObject(ctx, vni.Svis)
+		}
+		setMetadataValue(ctx, &newVni.Metadata, vni.Metadata)
+		setSetStringValue(ctx, &newVni.Labels, vni.Labels)
+		if vni.Annotations != nil {
+			newVni.Annotations = NewAnnotationsSet(ctx, vni.Annotations)
 		}
 	} else {
 		newVni.Id = basetypes.NewStringNull()
@@ -502,89 +490,30 @@ func getAndSetVniAttributes(ctx context.Context, diags *diag.Diagnostics, client
 	*data = newVni
 }
 
-func getVniJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *VniResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.FabricId.IsNull() && !data.FabricId.IsUnknown() {
-		payloadMap["fabricId"] = data.FabricId.ValueString()
+func getVniPayload(ctx context.Context, data *VniResourceModel) *client.Vni {
+	vni := &client.Vni{
+		Name:        getStringPayload(data.Name),
+		Description: getStringPayload(data.Description),
+		Enabled:     client.Bool(true),
+		VrfId:       getStringPayload(data.VrfId),
+		Vni:         getFloat64Payload(data.Vni),
+		Mtu:         getFloat64Payload(data.Mtu),
+		Labels:      getSetStringPayload(ctx, data.Labels),
+		Annotations: getAnnotationsPayload(ctx, data.Annotations),
 	}
 
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	payloadMap["enabled"] = true
-	// if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-	// 	payloadMap["enabled"] = data.Enabled.ValueBool()
-	// }
-
-	// if !data.IsDefault.IsNull() && !data.IsDefault.IsUnknown() {
-	// 	payloadMap["enabled"] = data.IsDefault.ValueBool()
-	// }
-
-	// if !data.IsL3.IsNull() && !data.IsL3.IsUnknown() {
-	// 	payloadMap["isL3"] = data.IsL3.ValueBool()
-	// }
-
-	if !data.VrfId.IsNull() && !data.VrfId.IsUnknown() {
-		payloadMap["vrfId"] = data.VrfId.ValueString()
-	}
-
-	if !data.Vni.IsNull() && !data.Vni.IsUnknown() {
-		payloadMap["vni"] = data.Vni.ValueFloat64()
-	}
-
-	if !data.Mtu.IsNull() && !data.Mtu.IsUnknown() {
-		payloadMap["mtu"] = data.Mtu.ValueFloat64()
+	if !data.FabricId.IsNull() && !data.FabricId.IsUnknown() {
+		vni.FabricId = data.FabricId.ValueString()
 	}
 
 	if !data.Members.IsNull() && !data.Members.IsUnknown() {
-		payloadMap["members"] = getMembersJsonPayload(ctx, data.Members)
+		vni.Members = getMembersJsonPayload(ctx, data.Members)
 	}
 
 	if !data.Svi.IsNull() && !data.Svi.IsUnknown() && !IsEmptySingleNestedAttribute(data.Svi.Attributes()) {
-		payloadMap["svis"] = getSviJsonPayload(ctx, data.Svi)
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	}
-
-	if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-		payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	}
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"vnis": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+		vni.Svis = getSviJsonPayload(ctx, data.Svi)
 	}
-	return jsonPayload
+	return vni
 }
 
 func checkAndSetVniIds(data *VniResourceModel) {
@@ -10,7 +10,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -113,6 +113,8 @@ func (r *VrfDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 
 func (r *VrfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of datasource: hyperfabric_vrf")
+	ctx, span := startSpan(ctx, "data.hyperfabric_vrf.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *VrfResourceModel
 
 	// Read Terraform prior state data into the model
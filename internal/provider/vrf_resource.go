@@ -8,12 +8,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/Jeffail/gabs/v2"
-	"github.com/cisco-open/terraform-provider-hyperfabric/internal/client"
+	"github.com/cisco-open/terraform-provider-hyperfabric/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -30,6 +28,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &VrfResource{}
 var _ resource.ResourceWithImportState = &VrfResource{}
+var _ resource.ResourceWithModifyPlan = &VrfResource{}
 
 func NewVrfResource() resource.Resource {
 	return &VrfResource{}
@@ -137,6 +136,10 @@ type VrfIdentifier struct {
 	Id types.String
 }
 
+func (r *VrfResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	setMetadataUnknownWhenUpdated(ctx, req, resp)
+}
+
 func (r *VrfResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	tflog.Debug(ctx, "Start metadata of resource: hyperfabric_vrf")
 	resp.TypeName = req.ProviderTypeName + "_vrf"
@@ -259,6 +262,8 @@ func (r *VrfResource) Configure(ctx context.Context, req resource.ConfigureReque
 
 func (r *VrfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Start create of resource: hyperfabric_vrf")
+	ctx, span := startSpan(ctx, "hyperfabric_vrf.Create")
+	defer endSpan(span, &resp.Diagnostics)
 
 	var data *VrfResourceModel
 
@@ -269,25 +274,16 @@ func (r *VrfResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Create of resource hyperfabric_vrf in fabric '%s' with VRF name '%s'", data.FabricId.ValueString(), data.Name.ValueString()))
 
-	jsonPayload := getVrfJsonPayload(ctx, &resp.Diagnostics, data, "create")
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	container := DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/vrfs", data.FabricId.ValueString()), "POST", jsonPayload)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	vrfContainer, err := container.ArrayElement(0, "vrfs")
+	vrf, err := r.client.Vrfs.Create(ctx, data.FabricId.ValueString(), getVrfPayload(ctx, data))
 	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.FabricId.ValueString())
 
-	vrfId := StripQuotes(vrfContainer.Search("id").String())
-	if vrfId != "" {
-		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vrfs/%s", data.FabricId.ValueString(), vrfId))
-		data.VrfId = basetypes.NewStringValue(vrfId)
+	if vrf != nil && vrf.Id != "" {
+		data.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vrfs/%s", data.FabricId.ValueString(), vrf.Id))
+		data.VrfId = basetypes.NewStringValue(vrf.Id)
 		getAndSetVrfAttributes(ctx, &resp.Diagnostics, r.client, data)
 	} else {
 		data.Id = basetypes.NewStringNull()
@@ -300,6 +296,8 @@ func (r *VrfResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 func (r *VrfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Start read of resource: hyperfabric_vrf")
+	ctx, span := startSpan(ctx, "hyperfabric_vrf.Read")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *VrfResourceModel
 
 	// Read Terraform prior state data into the model
@@ -325,6 +323,8 @@ func (r *VrfResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 func (r *VrfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Start update of resource: hyperfabric_vrf")
+	ctx, span := startSpan(ctx, "hyperfabric_vrf.Update")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *VrfResourceModel
 	var stateData *VrfResourceModel
 
@@ -338,17 +338,12 @@ func (r *VrfResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	tflog.Debug(ctx, fmt.Sprintf("Update of resource hyperfabric_vrf with id '%s'", data.Id.ValueString()))
 
-	jsonPayload := getVrfJsonPayload(ctx, &resp.Diagnostics, data, "update")
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/vrfs/%s", data.FabricId.ValueString(), data.VrfId.ValueString()), "PUT", jsonPayload)
-
-	if resp.Diagnostics.HasError() {
+	_, err := r.client.Vrfs.Update(withRevision(ctx, stateData.Metadata), data.FabricId.ValueString(), data.VrfId.ValueString(), getVrfPayload(ctx, data))
+	if err != nil {
+		AddClientAttributeError(ctx, &resp.Diagnostics, req.Plan.Schema, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 
 	getAndSetVrfAttributes(ctx, &resp.Diagnostics, r.client, data)
 
@@ -359,6 +354,8 @@ func (r *VrfResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 func (r *VrfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Start delete of resource: hyperfabric_vrf")
+	ctx, span := startSpan(ctx, "hyperfabric_vrf.Delete")
+	defer endSpan(span, &resp.Diagnostics)
 	var data *VrfResourceModel
 
 	// Read Terraform prior state data into the model
@@ -370,10 +367,12 @@ func (r *VrfResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 
 	tflog.Debug(ctx, fmt.Sprintf("Delete of resource hyperfabric_vrf with id '%s'", data.Id.ValueString()))
 	checkAndSetVrfIds(data)
-	DoRestRequest(ctx, &resp.Diagnostics, r.client, fmt.Sprintf("/api/v1/fabrics/%s/vrfs/%s", data.FabricId.ValueString(), data.VrfId.ValueString()), "DELETE", nil)
-	if resp.Diagnostics.HasError() {
+	err := r.client.Vrfs.Delete(withRevision(ctx, data.Metadata), data.FabricId.ValueString(), data.VrfId.ValueString())
+	if err != nil {
+		AddClientError(&resp.Diagnostics, err)
 		return
 	}
+	addChangedFabric(ctx, r.client, data.Id.ValueString())
 	tflog.Debug(ctx, fmt.Sprintf("End delete of resource hyperfabric_vrf with id '%s'", data.Id.ValueString()))
 }
 
@@ -387,43 +386,34 @@ func (r *VrfResource) ImportState(ctx context.Context, req resource.ImportStateR
 }
 
 func getAndSetVrfAttributes(ctx context.Context, diags *diag.Diagnostics, client *client.Client, data *VrfResourceModel) {
-	requestData := DoRestRequest(ctx, diags, client, fmt.Sprintf("/api/v1/fabrics/%s/vrfs/%s", data.FabricId.ValueString(), data.VrfId.ValueString()), "GET", nil)
-	if diags.HasError() {
+	vrf, err := client.Vrfs.Get(ctx, data.FabricId.ValueString(), data.VrfId.ValueString())
+	if err != nil {
+		AddClientError(diags, err)
 		return
 	}
 
 	newVrf := *getNewVrfResourceModelFromData(data)
 
-	if requestData.Data() != nil {
-		attributes := requestData.Data().(map[string]interface{})
-		for attributeName, attributeValue := range attributes {
-			if attributeName == "fabricId" && (data.FabricId.IsNull() || data.FabricId.IsUnknown() || data.FabricId.ValueString() == "" || data.FabricId.ValueString() != attributeValue.(string)) {
-				newVrf.FabricId = basetypes.NewStringValue(attributeValue.(string))
-				newVrf.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vrfs/%s", newVrf.FabricId.ValueString(), newVrf.VrfId.ValueString()))
-			} else if attributeName == "id" && (data.VrfId.IsNull() || data.VrfId.IsUnknown() || data.VrfId.ValueString() == "" || data.VrfId.ValueString() != attributeValue.(string)) {
-				newVrf.VrfId = basetypes.NewStringValue(attributeValue.(string))
-				newVrf.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vrfs/%s", newVrf.FabricId.ValueString(), newVrf.VrfId.ValueString()))
-			} else if attributeName == "name" {
-				newVrf.Name = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "description" {
-				newVrf.Description = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "enabled" {
-				newVrf.Enabled = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "isDefault" {
-				newVrf.IsDefault = basetypes.NewBoolValue(attributeValue.(bool))
-			} else if attributeName == "asn" {
-				newVrf.Asn = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "vni" {
-				newVrf.Vni = basetypes.NewFloat64Value(attributeValue.(float64))
-			} else if attributeName == "routeTarget" {
-				newVrf.RouteTarget = basetypes.NewStringValue(attributeValue.(string))
-			} else if attributeName == "metadata" {
-				newVrf.Metadata = NewMetadataObject(ctx, attributeValue.(map[string]interface{}))
-			} else if attributeName == "labels" {
-				newVrf.Labels = NewSetString(ctx, attributeValue.([]interface{}))
-			} else if attributeName == "annotations" {
-				newVrf.Annotations = NewAnnotationsSet(ctx, attributeValue.([]interface{}))
-			}
+	if vrf != nil {
+		if vrf.FabricId != "" && vrf.FabricId != data.FabricId.ValueString() {
+			newVrf.FabricId = basetypes.NewStringValue(vrf.FabricId)
+			newVrf.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vrfs/%s", newVrf.FabricId.ValueString(), newVrf.VrfId.ValueString()))
+		}
+		if vrf.Id != "" && vrf.Id != data.VrfId.ValueString() {
+			newVrf.VrfId = basetypes.NewStringValue(vrf.Id)
+			newVrf.Id = basetypes.NewStringValue(fmt.Sprintf("%s/vrfs/%s", newVrf.FabricId.ValueString(), newVrf.VrfId.ValueString()))
+		}
+		setStringValue(&newVrf.Name, vrf.Name)
+		setStringValue(&newVrf.Description, vrf.Description)
+		setBoolValue(&newVrf.Enabled, vrf.Enabled)
+		setBoolValue(&newVrf.IsDefault, vrf.IsDefault)
+		setFloat64Value(&newVrf.Asn, vrf.Asn)
+		setFloat64Value(&newVrf.Vni, vrf.Vni)
+		setStringValue(&newVrf.RouteTarget, vrf.RouteTarget)
+		setMetadataValue(ctx, &newVrf.Metadata, vrf.Metadata)
+		setSetStringValue(ctx, &newVrf.Labels, vrf.Labels)
+		if vrf.Annotations != nil {
+			newVrf.Annotations = NewAnnotationsSet(ctx, vrf.Annotations)
 		}
 	} else {
 		newVrf.Id = basetypes.NewStringNull()
@@ -431,71 +421,23 @@ func getAndSetVrfAttributes(ctx context.Context, diags *diag.Diagnostics, client
 	*data = newVrf
 }
 
-func getVrfJsonPayload(ctx context.Context, diags *diag.Diagnostics, data *VrfResourceModel, action string) *gabs.Container {
-	payloadMap := map[string]interface{}{}
-	payloadList := []map[string]interface{}{}
-
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		payloadMap["name"] = data.Name.ValueString()
+func getVrfPayload(ctx context.Context, data *VrfResourceModel) *client.Vrf {
+	vrf := &client.Vrf{
+		Name:        getStringPayload(data.Name),
+		Description: getStringPayload(data.Description),
+		// TO FIX
+		Enabled:     client.Bool(true),
+		Asn:         getFloat64Payload(data.Asn),
+		Vni:         getFloat64Payload(data.Vni),
+		Labels:      getSetStringPayload(ctx, data.Labels),
+		Annotations: getAnnotationsPayload(ctx, data.Annotations),
 	}
 
 	// TO FIX: NEED TO BE REMOVE WHEN FIXED
 	if !data.VrfId.IsNull() && !data.VrfId.IsUnknown() {
-		payloadMap["id"] = data.VrfId.ValueString()
-	}
-
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		payloadMap["description"] = data.Description.ValueString()
-	}
-
-	// TO FIX
-	payloadMap["enabled"] = true
-	// if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
-	// 	payloadMap["enabled"] = data.Enabled.ValueBool()
-	// }
-
-	if !data.Asn.IsNull() && !data.Asn.IsUnknown() {
-		payloadMap["asn"] = data.Asn.ValueFloat64()
-	}
-
-	if !data.Vni.IsNull() && !data.Vni.IsUnknown() {
-		payloadMap["vni"] = data.Vni.ValueFloat64()
-	}
-
-	if !data.Labels.IsNull() && !data.Labels.IsUnknown() {
-		payloadMap["labels"] = getSetStringJsonPayload(ctx, data.Labels)
-	}
-
-	if !data.Annotations.IsNull() && !data.Annotations.IsUnknown() {
-		payloadMap["annotations"] = getAnnotationsJsonPayload(ctx, data.Annotations)
-	}
-
-	var payload map[string]interface{}
-	if action == "create" {
-		payloadList = append(payloadList, payloadMap)
-		payload = map[string]interface{}{"vrfs": payloadList}
-	} else {
-		payload = payloadMap
-	}
-
-	marshalPayload, err := json.Marshal(payload)
-	if err != nil {
-		diags.AddError(
-			"Marshalling of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
-	}
-
-	jsonPayload, err := gabs.ParseJSON(marshalPayload)
-	if err != nil {
-		diags.AddError(
-			"Construction of JSON payload failed",
-			fmt.Sprintf("Err: %s. Please report this issue to the provider developers.", err),
-		)
-		return nil
+		vrf.Id = data.VrfId.ValueString()
 	}
-	return jsonPayload
+	return vrf
 }
 
 func checkAndSetVrfIds(data *VrfResourceModel) {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/cisco-open/terraform-provider-hyperfabric/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -47,10 +48,23 @@ func main() {
 		Address: "registry.terraform.io/cisco-open/hyperfabric",
 		Debug:   debug,
 	}
+	shutdownTracing, err := provider.StartTracing(context.Background(), version)
+	if err != nil {
+		log.Printf("[WARN] Tracing is disabled: %s", err)
+	}
 	providerInstance := provider.New(version)
-	err := providerserver.Serve(context.Background(), providerInstance, opts)
+	err = providerserver.Serve(context.Background(), providerInstance, opts)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	providerInstance().(*provider.HyperfabricProvider).DoAutoCommit()
+	provider.DoAutoCommit()
+	if err := provider.ReportStats(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+	// Terraform kills the provider 2 seconds after asking it to stop, the spans are flushed when its operations are done
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("[WARN] Export of the traces failed: %s", err)
+	}
 }